@@ -11,10 +11,19 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/citadel-corp/eniqilo-store/internal/cart"
 	"github.com/citadel-corp/eniqilo-store/internal/checkout"
 	"github.com/citadel-corp/eniqilo-store/internal/common/db"
 	"github.com/citadel-corp/eniqilo-store/internal/common/middleware"
+	"github.com/citadel-corp/eniqilo-store/internal/giftcard"
+	"github.com/citadel-corp/eniqilo-store/internal/location"
+	"github.com/citadel-corp/eniqilo-store/internal/loyalty"
 	"github.com/citadel-corp/eniqilo-store/internal/product"
+	"github.com/citadel-corp/eniqilo-store/internal/promotion"
+	"github.com/citadel-corp/eniqilo-store/internal/purchase"
+	"github.com/citadel-corp/eniqilo-store/internal/report"
+	"github.com/citadel-corp/eniqilo-store/internal/shift"
+	"github.com/citadel-corp/eniqilo-store/internal/tax"
 	"github.com/citadel-corp/eniqilo-store/internal/user"
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog"
@@ -53,16 +62,75 @@ func main() {
 	userService := user.NewService(userRepository)
 	userHandler := user.NewHandler(userService)
 
+	// initialize location domain
+	locationRepository := location.NewRepository(db)
+	locationService := location.NewService(locationRepository)
+	locationHandler := location.NewHandler(locationService)
+
 	// initialize product domain
 	productRepository := product.NewRepository(db)
-	productService := product.NewService(productRepository)
+	productService := product.NewService(productRepository, locationRepository)
 	productHandler := product.NewHandler(productService)
 
+	// initialize promotion domain
+	promotionRepository := promotion.NewRepository(db)
+	promotionService := promotion.NewService(promotionRepository, productRepository)
+	promotionHandler := promotion.NewHandler(promotionService)
+
+	// initialize tax domain
+	taxRepository := tax.NewRepository(db)
+	taxService := tax.NewService(taxRepository, productRepository)
+	taxHandler := tax.NewHandler(taxService)
+
+	// initialize shift domain
+	shiftRepository := shift.NewRepository(db)
+	shiftService := shift.NewService(shiftRepository, locationRepository)
+	shiftHandler := shift.NewHandler(shiftService)
+
+	// initialize loyalty domain
+	loyaltyRepository := loyalty.NewRepository(db)
+	loyaltyService := loyalty.NewService(loyaltyRepository)
+	loyaltyHandler := loyalty.NewHandler(loyaltyService)
+
+	// initialize gift card domain
+	giftCardRepository := giftcard.NewRepository(db)
+	giftCardService := giftcard.NewService(giftCardRepository, userRepository)
+	giftCardHandler := giftcard.NewHandler(giftCardService)
+
+	// initialize low stock notifier
+	lowStockNotifier := product.NewLogNotifier()
+	if path := os.Getenv("LOW_STOCK_ALERT_FILE"); path != "" {
+		lowStockNotifier = product.NewFileNotifier(path)
+	}
+
 	// initialize checkout domain
 	checkoutRepository := checkout.NewRepository(db)
-	checkoutService := checkout.NewService(checkoutRepository, userRepository, productRepository)
+	checkoutService := checkout.NewService(checkoutRepository, userRepository, productRepository, promotionRepository, taxRepository,
+		shiftRepository, loyaltyRepository, giftCardRepository, locationRepository, lowStockNotifier)
 	checkoutHandler := checkout.NewHandler(checkoutService)
 
+	// initialize cart domain
+	cartRepository := cart.NewRepository(db)
+	cartService := cart.NewService(cartRepository, userRepository, productRepository, locationRepository, checkoutService)
+	cartHandler := cart.NewHandler(cartService)
+
+	// initialize purchase domain
+	purchaseRepository := purchase.NewRepository(db)
+	purchaseService := purchase.NewService(purchaseRepository, productRepository, locationRepository)
+	purchaseHandler := purchase.NewHandler(purchaseService)
+
+	// initialize report domain
+	reportRepository := report.NewRepository(db)
+	reportService := report.NewService(reportRepository)
+	reportHandler := report.NewHandler(reportService)
+
+	// initialize idempotency middleware
+	idempotencyTTL, err := time.ParseDuration(os.Getenv("IDEMPOTENCY_TTL"))
+	if err != nil {
+		idempotencyTTL = 24 * time.Hour
+	}
+	idempotency := middleware.NewIdempotency(middleware.NewIdempotencyStore(db), idempotencyTTL)
+
 	r := mux.NewRouter()
 	r.Use(middleware.Logging)
 	r.Use(middleware.PanicRecoverer)
@@ -78,23 +146,100 @@ func main() {
 	sr.HandleFunc("/register", userHandler.CreateStaff).Methods(http.MethodPost)
 	sr.HandleFunc("/login", userHandler.StaffLogin).Methods(http.MethodPost)
 
+	// location routes
+	lor := v1.PathPrefix("/location").Subrouter()
+	lor.HandleFunc("", middleware.Authorized(locationHandler.CreateLocation)).Methods(http.MethodPost)
+	lor.HandleFunc("", middleware.Authorized(locationHandler.ListLocations)).Methods(http.MethodGet)
+
 	// product routes
 	pr := v1.PathPrefix("/product").Subrouter()
 	pr.HandleFunc("/customer", middleware.Authenticate(productHandler.ListProductForCustomer)).Methods(http.MethodGet)
-	pr.HandleFunc("", middleware.Authorized(productHandler.CreateProduct)).Methods(http.MethodPost)
+	pr.HandleFunc("/low-stock", middleware.Authorized(productHandler.ListLowStock)).Methods(http.MethodGet)
+	pr.HandleFunc("", middleware.Authorized(idempotency.Handle(productHandler.CreateProduct))).Methods(http.MethodPost)
 	pr.HandleFunc("/{id}", middleware.Authorized(productHandler.EditProduct)).Methods(http.MethodPut)
 	pr.HandleFunc("/{id}", middleware.Authorized(productHandler.DeleteProduct)).Methods(http.MethodDelete)
+	pr.HandleFunc("/{id}/stock-adjustments", middleware.Authorized(idempotency.Handle(productHandler.AdjustStock))).Methods(http.MethodPost)
+	pr.HandleFunc("/{id}/stock-movements", middleware.Authorized(productHandler.ListStockMovements)).Methods(http.MethodGet)
 	pr.HandleFunc("", middleware.Authorized(productHandler.ListProduct)).Methods(http.MethodGet)
 
 	// product checkout routes
 	pcr := pr.PathPrefix("/checkout").Subrouter()
-	pcr.HandleFunc("", middleware.Authorized(checkoutHandler.CheckoutProducts)).Methods(http.MethodPost)
+	pcr.HandleFunc("", middleware.Authorized(idempotency.Handle(checkoutHandler.CheckoutProducts))).Methods(http.MethodPost)
+	pcr.HandleFunc("/quote", middleware.Authorized(checkoutHandler.QuoteCheckout)).Methods(http.MethodPost)
 	pcr.HandleFunc("/history", middleware.Authorized(checkoutHandler.ListCheckoutHistories)).Methods(http.MethodGet)
+	pcr.HandleFunc("/parked", middleware.Authorized(checkoutHandler.ParkSale)).Methods(http.MethodPost)
+	pcr.HandleFunc("/parked", middleware.Authorized(checkoutHandler.ListParkedSales)).Methods(http.MethodGet)
+	pcr.HandleFunc("/parked/{id}", middleware.Authorized(checkoutHandler.GetParkedSale)).Methods(http.MethodGet)
+	pcr.HandleFunc("/parked/{id}", middleware.Authorized(checkoutHandler.DeleteParkedSale)).Methods(http.MethodDelete)
+	pcr.HandleFunc("/{transactionId}/receipt", middleware.Authorized(checkoutHandler.GetReceipt)).Methods(http.MethodGet)
+	pcr.HandleFunc("/{transactionId}/void", middleware.Authorized(checkoutHandler.VoidCheckout)).Methods(http.MethodPost)
+	pcr.HandleFunc("/{transactionId}/refund", middleware.Authorized(idempotency.Handle(checkoutHandler.RefundCheckout))).Methods(http.MethodPost)
+
+	// cart routes
+	car := v1.PathPrefix("/cart").Subrouter()
+	car.HandleFunc("", middleware.Authenticate(cartHandler.CreateCart)).Methods(http.MethodPost)
+	car.HandleFunc("/{id}", middleware.Authenticate(cartHandler.GetCart)).Methods(http.MethodGet)
+	car.HandleFunc("/{id}/items", middleware.Authenticate(cartHandler.AddItem)).Methods(http.MethodPost)
+	car.HandleFunc("/{id}/items/{productId}", middleware.Authenticate(cartHandler.UpdateItem)).Methods(http.MethodPut)
+	car.HandleFunc("/{id}/items/{productId}", middleware.Authenticate(cartHandler.RemoveItem)).Methods(http.MethodDelete)
+	car.HandleFunc("/{id}/checkout", middleware.Authorized(idempotency.Handle(cartHandler.CheckoutCart))).Methods(http.MethodPost)
+
+	// promotion routes
+	prr := v1.PathPrefix("/promotion").Subrouter()
+	prr.HandleFunc("", middleware.Authorized(promotionHandler.CreatePromotion)).Methods(http.MethodPost)
+	prr.HandleFunc("", middleware.Authorized(promotionHandler.ListPromotions)).Methods(http.MethodGet)
+	prr.HandleFunc("/{id}", middleware.Authorized(promotionHandler.DeletePromotion)).Methods(http.MethodDelete)
+
+	// tax rate routes
+	tr := v1.PathPrefix("/tax-rate").Subrouter()
+	tr.HandleFunc("", middleware.Authorized(taxHandler.SetTaxRate)).Methods(http.MethodPut)
+	tr.HandleFunc("", middleware.Authorized(taxHandler.ListTaxRates)).Methods(http.MethodGet)
+	tr.HandleFunc("/{id}", middleware.Authorized(taxHandler.DeleteTaxRate)).Methods(http.MethodDelete)
+
+	// shift routes
+	shr := v1.PathPrefix("/shift").Subrouter()
+	shr.HandleFunc("", middleware.Authorized(shiftHandler.OpenShift)).Methods(http.MethodPost)
+	shr.HandleFunc("/current", middleware.Authorized(shiftHandler.GetCurrentShift)).Methods(http.MethodGet)
+	shr.HandleFunc("/current/cash-movements", middleware.Authorized(shiftHandler.AddCashMovement)).Methods(http.MethodPost)
+	shr.HandleFunc("/current/close", middleware.Authorized(shiftHandler.CloseShift)).Methods(http.MethodPost)
+	shr.HandleFunc("/variance", middleware.Authorized(shiftHandler.VarianceReport)).Methods(http.MethodGet)
+
+	// loyalty routes
+	lr := v1.PathPrefix("/loyalty").Subrouter()
+	lr.HandleFunc("/multiplier", middleware.Authorized(loyaltyHandler.SetMultiplier)).Methods(http.MethodPut)
+	lr.HandleFunc("/multiplier", middleware.Authorized(loyaltyHandler.ListMultipliers)).Methods(http.MethodGet)
+	lr.HandleFunc("/multiplier/{category}", middleware.Authorized(loyaltyHandler.DeleteMultiplier)).Methods(http.MethodDelete)
+
+	// gift card routes
+	gcr := v1.PathPrefix("/gift-card").Subrouter()
+	gcr.HandleFunc("", middleware.Authorized(idempotency.Handle(giftCardHandler.IssueGiftCard))).Methods(http.MethodPost)
+	gcr.HandleFunc("/{code}", middleware.Authorized(giftCardHandler.GetGiftCard)).Methods(http.MethodGet)
+
+	// supplier routes
+	sur := v1.PathPrefix("/supplier").Subrouter()
+	sur.HandleFunc("", middleware.Authorized(purchaseHandler.CreateSupplier)).Methods(http.MethodPost)
+	sur.HandleFunc("", middleware.Authorized(purchaseHandler.ListSuppliers)).Methods(http.MethodGet)
+
+	// purchase order routes
+	por := v1.PathPrefix("/purchase-order").Subrouter()
+	por.HandleFunc("", middleware.Authorized(idempotency.Handle(purchaseHandler.CreatePurchaseOrder))).Methods(http.MethodPost)
+	por.HandleFunc("", middleware.Authorized(purchaseHandler.ListPurchaseOrders)).Methods(http.MethodGet)
+	por.HandleFunc("/{id}", middleware.Authorized(purchaseHandler.GetPurchaseOrder)).Methods(http.MethodGet)
+	por.HandleFunc("/{id}/receive", middleware.Authorized(idempotency.Handle(purchaseHandler.ReceivePurchaseOrder))).Methods(http.MethodPost)
+
+	// report routes
+	rr := v1.PathPrefix("/reports").Subrouter()
+	rr.HandleFunc("/sales", middleware.Authorized(reportHandler.SalesReport)).Methods(http.MethodGet)
+	rr.HandleFunc("/top-sellers", middleware.Authorized(reportHandler.TopSellers)).Methods(http.MethodGet)
+	rr.HandleFunc("/slow-movers", middleware.Authorized(reportHandler.SlowMovers)).Methods(http.MethodGet)
 
 	// customer routes
 	cr := v1.PathPrefix("/customer").Subrouter()
-	cr.HandleFunc("/register", middleware.Authorized(userHandler.CreateCustomer)).Methods(http.MethodPost)
+	cr.HandleFunc("/register", middleware.Authorized(idempotency.Handle(userHandler.CreateCustomer))).Methods(http.MethodPost)
 	cr.HandleFunc("", middleware.Authorized(userHandler.ListCustomers)).Methods(http.MethodGet)
+	cr.HandleFunc("/{id}/loyalty", middleware.Authorized(loyaltyHandler.GetAccount)).Methods(http.MethodGet)
+	cr.HandleFunc("/{id}/store-credit", middleware.Authorized(idempotency.Handle(giftCardHandler.IssueStoreCredit))).Methods(http.MethodPost)
+	cr.HandleFunc("/{id}/store-credit", middleware.Authorized(giftCardHandler.GetStoreCredit)).Methods(http.MethodGet)
 
 	httpServer := &http.Server{
 		Addr:    ":8080",
@@ -0,0 +1,58 @@
+package cart
+
+import (
+	"crypto/subtle"
+	"time"
+)
+
+// Cart is a basket for the store at LocationID. Its stock is checked there,
+// and checking it out takes stock from there. Token is handed to whoever
+// created the cart and is needed to use it without being logged in as staff.
+type Cart struct {
+	ID         string
+	CustomerID *string
+	LocationID string
+	Token      string
+	Items      []Item
+	CreatedAt  time.Time
+}
+
+// Access is who is using a cart: a logged in staff member, or whoever holds
+// the cart's token.
+type Access struct {
+	StaffID string
+	Token   string
+}
+
+// authorize checks that access may use cart. Staff may use any cart, as they
+// help customers and check carts out.
+func authorize(cart *Cart, access Access) error {
+	if access.StaffID != "" {
+		return nil
+	}
+	if access.Token == "" || subtle.ConstantTimeCompare([]byte(access.Token), []byte(cart.Token)) != 1 {
+		return ErrCartForbidden
+	}
+	return nil
+}
+
+// Item is a product in a cart. PriceAtAdd is the product price when the item
+// was last added or updated, used to tell the customer about price changes.
+type Item struct {
+	ProductID  string
+	Quantity   int
+	PriceAtAdd int64
+	AddedAt    time.Time
+}
+
+type ItemProblem string
+
+// Problems flagged on cart items when a cart is read. An item product that
+// was deleted, made unavailable or ran low on stock after it was added can no
+// longer be checked out as is.
+var (
+	ProblemRemoved        ItemProblem = "removed"
+	ProblemUnavailable    ItemProblem = "unavailable"
+	ProblemStockNotEnough ItemProblem = "stockNotEnough"
+	ProblemPriceChanged   ItemProblem = "priceChanged"
+)
@@ -0,0 +1,16 @@
+package cart
+
+import "errors"
+
+var (
+	ErrValidationFailed      = errors.New("validation failed")
+	ErrCustomerNotFound      = errors.New("customer not found")
+	ErrLocationNotFound      = errors.New("location not found")
+	ErrCartNotFound          = errors.New("cart not found")
+	ErrCartForbidden         = errors.New("cart belongs to someone else")
+	ErrItemNotFound          = errors.New("item is not in cart")
+	ErrProductNotFound       = errors.New("product not found")
+	ErrProductUnavailable    = errors.New("product is unavailable")
+	ErrProductStockNotEnough = errors.New("product stock is not enough")
+	ErrCartInvalid           = errors.New("one or more cart items can no longer be checked out")
+)
@@ -0,0 +1,213 @@
+package cart
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/citadel-corp/eniqilo-store/internal/checkout"
+	"github.com/citadel-corp/eniqilo-store/internal/common/middleware"
+	"github.com/citadel-corp/eniqilo-store/internal/common/request"
+	"github.com/citadel-corp/eniqilo-store/internal/common/response"
+	"github.com/gorilla/mux"
+)
+
+// TokenHeader carries the token a cart was created with, which is needed to
+// use the cart without being logged in as staff.
+const TokenHeader = "X-Cart-Token"
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) CreateCart(w http.ResponseWriter, r *http.Request) {
+	var req CreateCartPayload
+
+	err := request.DecodeJSON(w, r, &req)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Failed to decode JSON",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	resp, err := h.service.Create(r.Context(), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusCreated, response.ResponseBody{
+		Message: "success",
+		Data:    resp,
+	})
+}
+
+func (h *Handler) GetCart(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.Get(r.Context(), GetCartPayload{
+		CartID: mux.Vars(r)["id"],
+		Access: cartAccess(r),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "success",
+		Data:    resp,
+	})
+}
+
+func (h *Handler) AddItem(w http.ResponseWriter, r *http.Request) {
+	var req AddItemPayload
+
+	err := request.DecodeJSON(w, r, &req)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Failed to decode JSON",
+			Error:   err.Error(),
+		})
+		return
+	}
+	req.CartID = mux.Vars(r)["id"]
+	req.Access = cartAccess(r)
+
+	resp, err := h.service.AddItem(r.Context(), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "success",
+		Data:    resp,
+	})
+}
+
+func (h *Handler) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	var req UpdateItemPayload
+
+	err := request.DecodeJSON(w, r, &req)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Failed to decode JSON",
+			Error:   err.Error(),
+		})
+		return
+	}
+	params := mux.Vars(r)
+	req.CartID = params["id"]
+	req.ProductID = params["productId"]
+	req.Access = cartAccess(r)
+
+	resp, err := h.service.UpdateItem(r.Context(), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "success",
+		Data:    resp,
+	})
+}
+
+func (h *Handler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	resp, err := h.service.RemoveItem(r.Context(), RemoveItemPayload{
+		CartID:    params["id"],
+		Access:    cartAccess(r),
+		ProductID: params["productId"],
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "success",
+		Data:    resp,
+	})
+}
+
+func (h *Handler) CheckoutCart(w http.ResponseWriter, r *http.Request) {
+	var req CheckoutCartPayload
+
+	err := request.DecodeJSON(w, r, &req)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Failed to decode JSON",
+			Error:   err.Error(),
+		})
+		return
+	}
+	req.CartID = mux.Vars(r)["id"]
+	req.StaffID, _ = r.Context().Value(middleware.ContextAuthKey{}).(string)
+
+	resp, err := h.service.Checkout(r.Context(), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "success",
+		Data:    resp,
+	})
+}
+
+// cartAccess returns who is using a cart in r.
+func cartAccess(r *http.Request) Access {
+	staffID, _ := r.Context().Value(middleware.ContextAuthKey{}).(string)
+	return Access{
+		StaffID: staffID,
+		Token:   r.Header.Get(TokenHeader),
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrCartForbidden) {
+		response.JSON(w, http.StatusForbidden, response.ResponseBody{
+			Message: "Forbidden",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, ErrCartNotFound) ||
+		errors.Is(err, ErrItemNotFound) ||
+		errors.Is(err, ErrCustomerNotFound) ||
+		errors.Is(err, ErrLocationNotFound) ||
+		errors.Is(err, ErrProductNotFound) ||
+		errors.Is(err, checkout.ErrCartNotFound) ||
+		errors.Is(err, checkout.ErrLocationNotFound) ||
+		errors.Is(err, checkout.ErrCustomerNotFound) ||
+		errors.Is(err, checkout.ErrProductNotFound) ||
+		errors.Is(err, checkout.ErrGiftCardNotFound) {
+		response.JSON(w, http.StatusNotFound, response.ResponseBody{
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, ErrValidationFailed) ||
+		errors.Is(err, ErrProductUnavailable) ||
+		errors.Is(err, ErrProductStockNotEnough) ||
+		errors.Is(err, ErrCartInvalid) ||
+		errors.Is(err, checkout.ErrValidationFailed) ||
+		errors.Is(err, checkout.ErrProductUnavailable) ||
+		errors.Is(err, checkout.ErrProductStockNotEnough) ||
+		errors.Is(err, checkout.ErrNotEnoughMoney) ||
+		errors.Is(err, checkout.ErrNonCashOverpaid) ||
+		errors.Is(err, checkout.ErrNotEnoughPoints) ||
+		errors.Is(err, checkout.ErrGiftCardBalanceLow) ||
+		errors.Is(err, checkout.ErrWrongChange) {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+		Message: "Internal server error",
+		Error:   err.Error(),
+	})
+}
@@ -0,0 +1,134 @@
+package cart
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/db"
+)
+
+type Repository interface {
+	Create(ctx context.Context, cart *Cart) error
+	GetByID(ctx context.Context, id string) (*Cart, error)
+	AddItem(ctx context.Context, cartID string, item *Item) error
+	UpdateItem(ctx context.Context, cartID string, item *Item) error
+	RemoveItem(ctx context.Context, cartID string, productID string) error
+}
+
+type dbRepository struct {
+	db *db.DB
+}
+
+func NewRepository(db *db.DB) Repository {
+	return &dbRepository{db: db}
+}
+
+// Create implements Repository.
+func (d *dbRepository) Create(ctx context.Context, cart *Cart) error {
+	q := `
+		INSERT INTO carts (
+			id, customer_id, location_id, token
+		) VALUES (
+			$1, $2, $3, $4
+		) RETURNING created_at;
+	`
+	return d.db.DB().QueryRowContext(ctx, q, cart.ID, cart.CustomerID, cart.LocationID, cart.Token).Scan(&cart.CreatedAt)
+}
+
+// GetByID implements Repository.
+func (d *dbRepository) GetByID(ctx context.Context, id string) (*Cart, error) {
+	q := `
+		SELECT id, customer_id, location_id, token, created_at
+		FROM carts
+		WHERE id = $1;
+	`
+	cart := &Cart{}
+	err := d.db.DB().QueryRowContext(ctx, q, id).Scan(&cart.ID, &cart.CustomerID, &cart.LocationID, &cart.Token, &cart.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCartNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	q = `
+		SELECT product_id, quantity, price_at_add, added_at
+		FROM cart_items
+		WHERE cart_id = $1
+		ORDER BY added_at ASC;
+	`
+	rows, err := d.db.DB().QueryContext(ctx, q, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cart.Items = make([]Item, 0)
+	for rows.Next() {
+		item := Item{}
+		err := rows.Scan(&item.ProductID, &item.Quantity, &item.PriceAtAdd, &item.AddedAt)
+		if err != nil {
+			return nil, err
+		}
+		cart.Items = append(cart.Items, item)
+	}
+	return cart, rows.Err()
+}
+
+// AddItem implements Repository.
+// Adding a product that is already in the cart increases its quantity.
+func (d *dbRepository) AddItem(ctx context.Context, cartID string, item *Item) error {
+	q := `
+		INSERT INTO cart_items (
+			cart_id, product_id, quantity, price_at_add
+		) VALUES (
+			$1, $2, $3, $4
+		)
+		ON CONFLICT (cart_id, product_id) DO UPDATE
+		SET quantity = cart_items.quantity + EXCLUDED.quantity, price_at_add = EXCLUDED.price_at_add,
+			added_at = current_timestamp
+		RETURNING quantity, added_at;
+	`
+	return d.db.DB().QueryRowContext(ctx, q, cartID, item.ProductID, item.Quantity, item.PriceAtAdd).Scan(&item.Quantity, &item.AddedAt)
+}
+
+// UpdateItem implements Repository.
+func (d *dbRepository) UpdateItem(ctx context.Context, cartID string, item *Item) error {
+	q := `
+		UPDATE cart_items
+		SET quantity = $1, price_at_add = $2, added_at = current_timestamp
+		WHERE cart_id = $3 AND product_id = $4;
+	`
+	row, err := d.db.DB().ExecContext(ctx, q, item.Quantity, item.PriceAtAdd, cartID, item.ProductID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := row.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrItemNotFound
+	}
+	return nil
+}
+
+// RemoveItem implements Repository.
+func (d *dbRepository) RemoveItem(ctx context.Context, cartID string, productID string) error {
+	q := `
+		DELETE FROM cart_items
+		WHERE cart_id = $1 AND product_id = $2;
+	`
+	row, err := d.db.DB().ExecContext(ctx, q, cartID, productID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := row.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrItemNotFound
+	}
+	return nil
+}
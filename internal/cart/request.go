@@ -0,0 +1,88 @@
+package cart
+
+import (
+	"github.com/citadel-corp/eniqilo-store/internal/checkout"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type CreateCartPayload struct {
+	CustomerID *string `json:"customerId"`
+	LocationID string  `json:"locationId"`
+}
+
+func (p CreateCartPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.CustomerID, validation.NilOrNotEmpty),
+	)
+}
+
+type GetCartPayload struct {
+	CartID string
+	Access Access
+}
+
+func (p GetCartPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.CartID, validation.Required),
+	)
+}
+
+type AddItemPayload struct {
+	CartID    string `json:"-"`
+	Access    Access `json:"-"`
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+}
+
+func (p AddItemPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.CartID, validation.Required),
+		validation.Field(&p.ProductID, validation.Required),
+		validation.Field(&p.Quantity, validation.Required, validation.Min(1)),
+	)
+}
+
+type UpdateItemPayload struct {
+	CartID    string `json:"-"`
+	Access    Access `json:"-"`
+	ProductID string `json:"-"`
+	Quantity  int    `json:"quantity"`
+}
+
+func (p UpdateItemPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.CartID, validation.Required),
+		validation.Field(&p.ProductID, validation.Required),
+		validation.Field(&p.Quantity, validation.Required, validation.Min(1)),
+	)
+}
+
+type RemoveItemPayload struct {
+	CartID    string
+	Access    Access
+	ProductID string
+}
+
+func (p RemoveItemPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.CartID, validation.Required),
+		validation.Field(&p.ProductID, validation.Required),
+	)
+}
+
+// CheckoutCartPayload converts a cart into a checkout. The customer defaults
+// to the cart's customer.
+type CheckoutCartPayload struct {
+	CartID     string                    `json:"-"`
+	StaffID    string                    `json:"-"`
+	CustomerID string                    `json:"customerId"`
+	Paid       int                       `json:"paid"`
+	Payments   []checkout.PaymentRequest `json:"payments"`
+	Change     *int                      `json:"change"`
+}
+
+func (p CheckoutCartPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.CartID, validation.Required),
+	)
+}
@@ -0,0 +1,35 @@
+package cart
+
+import "time"
+
+// CartResponse is priced the same way as a checkout quote, so Total is what
+// checking out the cart would charge after promotions and tax. Token is only
+// returned when the cart is created.
+type CartResponse struct {
+	ID           string         `json:"id"`
+	Token        string         `json:"token,omitempty"`
+	CustomerID   *string        `json:"customerId"`
+	LocationID   string         `json:"locationId"`
+	Items        []ItemResponse `json:"items"`
+	Subtotal     int64          `json:"subtotal"`
+	Discount     int64          `json:"discount"`
+	Tax          int64          `json:"tax"`
+	TaxInclusive bool           `json:"taxInclusive"`
+	Total        int64          `json:"total"`
+	IsValid      bool           `json:"isValid"`
+	CreatedAt    time.Time      `json:"createdAt"`
+}
+
+// ItemResponse shows an item with the live product name, price and stock.
+type ItemResponse struct {
+	ProductID  string        `json:"productId"`
+	Name       string        `json:"name"`
+	Quantity   int           `json:"quantity"`
+	Price      int64         `json:"price"`
+	PriceAtAdd int64         `json:"priceAtAdd"`
+	Discount   int64         `json:"discount"`
+	Tax        int64         `json:"tax"`
+	Total      int64         `json:"total"`
+	Problems   []ItemProblem `json:"problems"`
+	AddedAt    time.Time     `json:"addedAt"`
+}
@@ -0,0 +1,337 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/citadel-corp/eniqilo-store/internal/checkout"
+	"github.com/citadel-corp/eniqilo-store/internal/common/id"
+	"github.com/citadel-corp/eniqilo-store/internal/location"
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+	"github.com/citadel-corp/eniqilo-store/internal/user"
+)
+
+type Service interface {
+	Create(ctx context.Context, req CreateCartPayload) (*CartResponse, error)
+	Get(ctx context.Context, req GetCartPayload) (*CartResponse, error)
+	AddItem(ctx context.Context, req AddItemPayload) (*CartResponse, error)
+	UpdateItem(ctx context.Context, req UpdateItemPayload) (*CartResponse, error)
+	RemoveItem(ctx context.Context, req RemoveItemPayload) (*CartResponse, error)
+	Checkout(ctx context.Context, req CheckoutCartPayload) (*checkout.CheckoutHistoryResponse, error)
+}
+
+type cartService struct {
+	repository         Repository
+	userRepository     user.Repository
+	productRepository  product.Repository
+	locationRepository location.Repository
+	checkoutService    checkout.Service
+}
+
+func NewService(repository Repository, userRepository user.Repository, productRepository product.Repository,
+	locationRepository location.Repository, checkoutService checkout.Service) Service {
+	return &cartService{
+		repository:         repository,
+		userRepository:     userRepository,
+		productRepository:  productRepository,
+		locationRepository: locationRepository,
+		checkoutService:    checkoutService,
+	}
+}
+
+// Create implements Service.
+func (s *cartService) Create(ctx context.Context, req CreateCartPayload) (*CartResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if req.CustomerID != nil {
+		_, err := s.userRepository.GetByID(ctx, *req.CustomerID)
+		if errors.Is(err, user.ErrUserNotFound) {
+			return nil, ErrCustomerNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	locationID := location.DefaultID
+	if req.LocationID != "" {
+		_, err := s.locationRepository.GetByID(ctx, req.LocationID)
+		if errors.Is(err, location.ErrLocationNotFound) {
+			return nil, ErrLocationNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		locationID = req.LocationID
+	}
+	cart := &Cart{
+		ID:         id.GenerateStringID(16),
+		CustomerID: req.CustomerID,
+		LocationID: locationID,
+		Token:      id.GenerateStringID(32),
+		Items:      make([]Item, 0),
+	}
+	err := s.repository.Create(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.toCartResponse(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+	res.Token = cart.Token
+	return res, nil
+}
+
+// Get implements Service.
+func (s *cartService) Get(ctx context.Context, req GetCartPayload) (*CartResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	cart, err := s.getCart(ctx, req.CartID, req.Access)
+	if err != nil {
+		return nil, err
+	}
+	return s.toCartResponse(ctx, cart)
+}
+
+// getCart returns the cart with the given id if access may use it.
+func (s *cartService) getCart(ctx context.Context, id string, access Access) (*Cart, error) {
+	cart, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorize(cart, access); err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+// get returns the cart with the given id once a change to it has been
+// authorized.
+func (s *cartService) get(ctx context.Context, id string) (*CartResponse, error) {
+	cart, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toCartResponse(ctx, cart)
+}
+
+// AddItem implements Service.
+func (s *cartService) AddItem(ctx context.Context, req AddItemPayload) (*CartResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	cart, err := s.getCart(ctx, req.CartID, req.Access)
+	if err != nil {
+		return nil, err
+	}
+	quantity := req.Quantity
+	for _, item := range cart.Items {
+		if item.ProductID == req.ProductID {
+			quantity += item.Quantity
+		}
+	}
+	p, err := s.getSellableProduct(ctx, req.ProductID, cart.LocationID, quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.AddItem(ctx, cart.ID, &Item{
+		ProductID:  p.ID,
+		Quantity:   req.Quantity,
+		PriceAtAdd: p.Price,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.get(ctx, cart.ID)
+}
+
+// UpdateItem implements Service.
+func (s *cartService) UpdateItem(ctx context.Context, req UpdateItemPayload) (*CartResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	cart, err := s.getCart(ctx, req.CartID, req.Access)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.getSellableProduct(ctx, req.ProductID, cart.LocationID, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	err = s.repository.UpdateItem(ctx, req.CartID, &Item{
+		ProductID:  p.ID,
+		Quantity:   req.Quantity,
+		PriceAtAdd: p.Price,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.get(ctx, req.CartID)
+}
+
+// RemoveItem implements Service.
+func (s *cartService) RemoveItem(ctx context.Context, req RemoveItemPayload) (*CartResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	_, err := s.getCart(ctx, req.CartID, req.Access)
+	if err != nil {
+		return nil, err
+	}
+	err = s.repository.RemoveItem(ctx, req.CartID, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	return s.get(ctx, req.CartID)
+}
+
+// Checkout implements Service.
+// The cart is revalidated and checked out as a regular checkout by the staff
+// member, taking stock from the cart's location. The checkout deletes the
+// cart in its own transaction, so a cart that is checked out twice at the
+// same time is only sold once.
+func (s *cartService) Checkout(ctx context.Context, req CheckoutCartPayload) (*checkout.CheckoutHistoryResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	cart, err := s.get(ctx, req.CartID)
+	if err != nil {
+		return nil, err
+	}
+	if !cart.IsValid {
+		return nil, ErrCartInvalid
+	}
+
+	customerID := req.CustomerID
+	if customerID == "" && cart.CustomerID != nil {
+		customerID = *cart.CustomerID
+	}
+	productDetails := make([]checkout.ProductDetailRequest, len(cart.Items))
+	for i, item := range cart.Items {
+		productDetails[i] = checkout.ProductDetailRequest{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		}
+	}
+	res, err := s.checkoutService.CheckoutProducts(ctx, checkout.CheckoutRequest{
+		StaffID:        req.StaffID,
+		LocationID:     cart.LocationID,
+		CartID:         cart.ID,
+		CustomerID:     customerID,
+		ProductDetails: productDetails,
+		Paid:           req.Paid,
+		Payments:       req.Payments,
+		Change:         req.Change,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *cartService) getSellableProduct(ctx context.Context, productID, locationID string, quantity int) (*product.Product, error) {
+	products, err := s.productRepository.GetByMultipleID(ctx, []string{productID})
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, ErrProductNotFound
+	}
+	p := products[0]
+	if !p.IsAvailable {
+		return nil, ErrProductUnavailable
+	}
+	if p.StockAt(locationID) < quantity {
+		return nil, ErrProductStockNotEnough
+	}
+	return p, nil
+}
+
+// toCartResponse prices the cart through the checkout quote, with live product
+// data, promotions and tax, and flags items that can no longer be checked out
+// as they were added.
+func (s *cartService) toCartResponse(ctx context.Context, cart *Cart) (*CartResponse, error) {
+	productIDs := make([]string, len(cart.Items))
+	for i, item := range cart.Items {
+		productIDs[i] = item.ProductID
+	}
+	products, err := s.productRepository.GetByMultipleID(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	productByID := make(map[string]*product.Product, len(products))
+	for _, p := range products {
+		productByID[p.ID] = p
+	}
+	quote := &checkout.QuoteResponse{ProductDetails: make([]checkout.QuoteLineResponse, len(cart.Items))}
+	if len(cart.Items) > 0 {
+		productDetails := make([]checkout.ProductDetailRequest, len(cart.Items))
+		for i, item := range cart.Items {
+			productDetails[i] = checkout.ProductDetailRequest{
+				ProductID: item.ProductID,
+				Quantity:  item.Quantity,
+			}
+		}
+		quote, err = s.checkoutService.QuoteCheckout(ctx, checkout.QuoteRequest{
+			LocationID:     cart.LocationID,
+			ProductDetails: productDetails,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := &CartResponse{
+		ID:           cart.ID,
+		CustomerID:   cart.CustomerID,
+		LocationID:   cart.LocationID,
+		Items:        make([]ItemResponse, len(cart.Items)),
+		Subtotal:     quote.Subtotal,
+		Discount:     quote.Discount,
+		Tax:          quote.Tax,
+		TaxInclusive: quote.TaxInclusive,
+		Total:        quote.AmountDue,
+		IsValid:      true,
+		CreatedAt:    cart.CreatedAt,
+	}
+	for i, item := range cart.Items {
+		itemRes := ItemResponse{
+			ProductID:  item.ProductID,
+			Quantity:   item.Quantity,
+			PriceAtAdd: item.PriceAtAdd,
+			Problems:   make([]ItemProblem, 0),
+			AddedAt:    item.AddedAt,
+		}
+		p, ok := productByID[item.ProductID]
+		if !ok {
+			itemRes.Problems = append(itemRes.Problems, ProblemRemoved)
+			res.IsValid = false
+			res.Items[i] = itemRes
+			continue
+		}
+		line := quote.ProductDetails[i]
+		itemRes.Name = p.Name
+		itemRes.Price = p.Price
+		itemRes.Discount = line.Discount
+		itemRes.Tax = line.Tax
+		itemRes.Total = line.Total
+		if !p.IsAvailable {
+			itemRes.Problems = append(itemRes.Problems, ProblemUnavailable)
+			res.IsValid = false
+		}
+		if p.StockAt(cart.LocationID) < item.Quantity {
+			itemRes.Problems = append(itemRes.Problems, ProblemStockNotEnough)
+			res.IsValid = false
+		}
+		// a price change is worth pointing out but does not block checkout
+		if p.Price != item.PriceAtAdd {
+			itemRes.Problems = append(itemRes.Problems, ProblemPriceChanged)
+		}
+		res.Items[i] = itemRes
+	}
+	return res, nil
+}
@@ -5,20 +5,78 @@ import (
 	"encoding/json"
 	"errors"
 	"time"
+
+	"github.com/citadel-corp/eniqilo-store/internal/giftcard"
+	"github.com/citadel-corp/eniqilo-store/internal/loyalty"
+	"github.com/citadel-corp/eniqilo-store/internal/product"
 )
 
+// CheckoutHistory is a completed sale. LocationID is where the sold stock was
+// taken from. Rounding is what was added to the amount due to round the cash
+// part of the payment, and may be negative.
 type CheckoutHistory struct {
 	ID             string
 	UserID         string
+	StaffID        string
+	ShiftID        *string
+	LocationID     string
 	ProductDetails ProductDetails
 	Paid           int
 	Change         int
-	CreatedAt      time.Time
+	Rounding       int
+	Tax            int64
+	TaxInclusive   bool
+	Payments       []Payment
+	VoidedAt       *time.Time
+	VoidedBy       *string
+	// VoidShiftID is the shift the cash was handed back from on a void.
+	VoidShiftID *string
+	CreatedAt   time.Time
+
+	// ParkedSaleID is the parked sale this checkout resumes, if any. It is not
+	// stored; the parked sale is removed when the checkout is created.
+	ParkedSaleID string
+	// CartID is the cart this checkout converts, if any. Like ParkedSaleID it
+	// is not stored; the cart is removed when the checkout is created.
+	CartID string
+	// LoyaltyEntries are applied to the customer's points balance in the same
+	// transaction that creates or voids the checkout.
+	LoyaltyEntries []loyalty.Entry
+	// GiftCardTransactions redeem gift cards and store credit, or give them
+	// back on a void, in the same transaction.
+	GiftCardTransactions []giftcard.Transaction
+	// StockMovements take sold products out of stock, or put them back on a
+	// void, in the same transaction.
+	StockMovements []product.StockMovement
+	// LowStockAlerts are raised for the products the checkout took to or
+	// below their reorder point. They are sent once the checkout is stored.
+	LowStockAlerts []product.LowStockAlert
 }
 
+// ProductDetail is a single line of a checkout. Name, SKU, Category and Price
+// are copied from the product at sale time so that later edits or deletion of
+// the product do not change what the receipt says was charged. Total is the
+// amount charged for the line: after Discount, and including Tax whether or
+// not prices were tax-inclusive. TaxRate is in basis points.
 type ProductDetail struct {
-	ProductID string
-	Quantity  int
+	ProductID   string
+	Quantity    int
+	Name        string
+	SKU         string
+	Category    product.ProductCategory
+	Price       int64
+	Discount    int64
+	PromotionID string
+	TaxRate     int
+	Tax         int64
+	Total       int64
+	// Points are the loyalty points the customer earned on the line.
+	Points int
+}
+
+// Subtotal returns the list price of the line before discount and tax.
+func (d ProductDetail) Subtotal() int64 {
+	return d.Price * int64(d.Quantity)
 }
 
 type ProductDetails []ProductDetail
@@ -39,3 +97,63 @@ func (a *ProductDetails) Scan(value interface{}) error {
 
 	return json.Unmarshal(b, &a)
 }
+
+type Refund struct {
+	ID                string
+	CheckoutHistoryID string
+	ShiftID           *string
+	ProductDetails    RefundDetails
+	Amount            int64
+	StoreCredit       bool
+	// Method is how Amount was paid out, and is empty for refunds to store
+	// credit.
+	Method    PaymentMethod
+	CreatedAt time.Time
+	// LoyaltyEntries claw back the points earned on the refunded lines.
+	LoyaltyEntries []loyalty.Entry
+	// StoreCreditTransaction credits Amount to the customer's store credit
+	// when StoreCredit is set. A customer without store credit is given an
+	// account with the transaction's GiftCardID.
+	StoreCreditTransaction *giftcard.Transaction
+	// StockMovements restock the refunded lines that are not damaged.
+	StockMovements []product.StockMovement
+}
+
+// RefundDetail is a single refunded line. Damaged lines are restocked into
+// the product's damaged stock instead of its sellable stock.
+type RefundDetail struct {
+	ProductID string
+	Quantity  int
+	Price     int64
+	Tax       int64
+	Total     int64
+	Points    int
+	Damaged   bool
+}
+
+type RefundDetails []RefundDetail
+
+func (a RefundDetails) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+func (a *RefundDetails) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &a)
+}
+
+// ParkedSale is a basket put aside before payment so the register can serve
+// someone else. Parking does not reserve stock; it is checked on checkout.
+type ParkedSale struct {
+	ID             string
+	StaffID        string
+	Register       string
+	CustomerID     string
+	ProductDetails ProductDetails
+	CreatedAt      time.Time
+	ExpiresAt      time.Time
+}
@@ -9,5 +9,22 @@ var (
 	ErrProductStockNotEnough = errors.New("product stock is not enough")
 	ErrNotEnoughMoney        = errors.New("not enough money paid")
 	ErrWrongChange           = errors.New("wrong change")
+	ErrNonCashOverpaid       = errors.New("non-cash payments cannot exceed the amount due")
 	ErrValidationFailed      = errors.New("validation failed")
+	ErrCheckoutNotFound      = errors.New("transaction id is not found")
+	ErrProductNotInCheckout  = errors.New("product is not part of the transaction")
+	ErrRefundExceedsSold     = errors.New("refund quantity exceeds quantity sold")
+	ErrCheckoutVoided        = errors.New("transaction is voided")
+	ErrCheckoutRefunded      = errors.New("transaction has refunds and cannot be voided")
+	ErrVoidWindowExpired     = errors.New("transaction can no longer be voided")
+	ErrParkedSaleNotFound    = errors.New("parked sale is not found")
+	ErrCartNotFound          = errors.New("cart is not found")
+	ErrGiftCardNotFound      = errors.New("gift card is not found")
+	ErrGiftCardBalanceLow    = errors.New("gift card or store credit balance is not enough")
+	ErrNotEnoughPoints       = errors.New("customer does not have enough loyalty points")
+	ErrUnknownReceiptFormat  = errors.New("unknown receipt format")
+	ErrVoidForbidden         = errors.New("only the staff who made the transaction or a manager can void it")
+	ErrLocationNotFound      = errors.New("location not found")
+	ErrRefundMethodNotPaid   = errors.New("transaction was not paid with the refund method")
+	ErrRefundExceedsPaid     = errors.New("refund amount exceeds what was paid with the refund method")
 )
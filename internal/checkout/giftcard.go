@@ -0,0 +1,74 @@
+package checkout
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/id"
+	"github.com/citadel-corp/eniqilo-store/internal/giftcard"
+)
+
+// giftCardRedemptions returns the transactions that take gift card and store
+// credit payments of ch off their cards.
+func (s *checkoutService) giftCardRedemptions(ctx context.Context, ch *CheckoutHistory) ([]giftcard.Transaction, error) {
+	transactions := make([]giftcard.Transaction, 0)
+	for _, payment := range ch.Payments {
+		var card *giftcard.GiftCard
+		var err error
+		switch payment.Method {
+		case PaymentGiftCard:
+			card, err = s.giftCardRepository.GetByCode(ctx, payment.Reference)
+			if errors.Is(err, giftcard.ErrGiftCardNotFound) {
+				return nil, ErrGiftCardNotFound
+			}
+		case PaymentStoreCredit:
+			card, err = s.giftCardRepository.GetByCustomerID(ctx, ch.UserID)
+			if errors.Is(err, giftcard.ErrGiftCardNotFound) {
+				return nil, ErrGiftCardBalanceLow
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, giftcard.Transaction{
+			ID:                id.GenerateStringID(16),
+			GiftCardID:        card.ID,
+			CheckoutHistoryID: &ch.ID,
+			Type:              giftcard.TransactionRedeem,
+			Amount:            -int64(payment.Amount),
+		})
+	}
+	return transactions, nil
+}
+
+// giftCardReversals returns the transactions that give back what a checkout
+// redeemed from gift cards and store credit.
+func (s *checkoutService) giftCardReversals(ctx context.Context, checkoutHistoryID string) ([]giftcard.Transaction, error) {
+	redemptions, err := s.giftCardRepository.ListTransactionsByCheckout(ctx, checkoutHistoryID)
+	if err != nil {
+		return nil, err
+	}
+	transactions := make([]giftcard.Transaction, 0, len(redemptions))
+	for _, redemption := range redemptions {
+		transactions = append(transactions, giftcard.Transaction{
+			ID:                id.GenerateStringID(16),
+			GiftCardID:        redemption.GiftCardID,
+			CheckoutHistoryID: redemption.CheckoutHistoryID,
+			Type:              giftcard.TransactionReversal,
+			Amount:            -redemption.Amount,
+		})
+	}
+	return transactions, nil
+}
+
+// maskGiftCardCode hides all but the last 4 characters of a gift card code, as
+// the code is all it takes to redeem the card.
+func maskGiftCardCode(code string) string {
+	if len(code) <= 4 {
+		return strings.Repeat("*", len(code))
+	}
+	return strings.Repeat("*", len(code)-4) + code[len(code)-4:]
+}
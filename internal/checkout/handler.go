@@ -4,8 +4,10 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/citadel-corp/eniqilo-store/internal/common/middleware"
 	"github.com/citadel-corp/eniqilo-store/internal/common/request"
 	"github.com/citadel-corp/eniqilo-store/internal/common/response"
+	"github.com/gorilla/mux"
 	"github.com/gorilla/schema"
 )
 
@@ -29,9 +31,14 @@ func (h *Handler) CheckoutProducts(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.service.CheckoutProducts(r.Context(), req)
+	req.StaffID, _ = r.Context().Value(middleware.ContextAuthKey{}).(string)
+
+	resp, err := h.service.CheckoutProducts(r.Context(), req)
 	if errors.Is(err, ErrCustomerNotFound) ||
-		errors.Is(err, ErrProductNotFound) {
+		errors.Is(err, ErrProductNotFound) ||
+		errors.Is(err, ErrParkedSaleNotFound) ||
+		errors.Is(err, ErrGiftCardNotFound) ||
+		errors.Is(err, ErrLocationNotFound) {
 		response.JSON(w, http.StatusNotFound, response.ResponseBody{
 			Message: "Not found",
 			Error:   err.Error(),
@@ -42,6 +49,9 @@ func (h *Handler) CheckoutProducts(w http.ResponseWriter, r *http.Request) {
 		errors.Is(err, ErrProductUnavailable) ||
 		errors.Is(err, ErrProductStockNotEnough) ||
 		errors.Is(err, ErrNotEnoughMoney) ||
+		errors.Is(err, ErrNonCashOverpaid) ||
+		errors.Is(err, ErrNotEnoughPoints) ||
+		errors.Is(err, ErrGiftCardBalanceLow) ||
 		errors.Is(err, ErrWrongChange) {
 		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
 			Message: "Bad request",
@@ -58,6 +68,48 @@ func (h *Handler) CheckoutProducts(w http.ResponseWriter, r *http.Request) {
 	}
 	response.JSON(w, http.StatusOK, response.ResponseBody{
 		Message: "success",
+		Data:    resp,
+	})
+}
+
+func (h *Handler) QuoteCheckout(w http.ResponseWriter, r *http.Request) {
+	var req QuoteRequest
+
+	err := request.DecodeJSON(w, r, &req)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Failed to decode JSON",
+			Error:   err.Error(),
+		})
+		return
+	}
+	req.StaffID, _ = r.Context().Value(middleware.ContextAuthKey{}).(string)
+
+	quote, err := h.service.QuoteCheckout(r.Context(), req)
+	if errors.Is(err, ErrLocationNotFound) {
+		response.JSON(w, http.StatusNotFound, response.ResponseBody{
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, ErrValidationFailed) {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "success",
+		Data:    quote,
 	})
 }
 
@@ -71,7 +123,14 @@ func (h *Handler) ListCheckoutHistories(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	histories, err := h.service.ListCheckoutHistories(r.Context(), req)
+	histories, pagination, err := h.service.ListCheckoutHistories(r.Context(), req)
+	if errors.Is(err, ErrValidationFailed) {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
 			Message: "Internal server error",
@@ -82,5 +141,256 @@ func (h *Handler) ListCheckoutHistories(w http.ResponseWriter, r *http.Request)
 	response.JSON(w, http.StatusOK, response.ResponseBody{
 		Message: "success",
 		Data:    histories,
+		Meta:    pagination,
+	})
+}
+
+func (h *Handler) RefundCheckout(w http.ResponseWriter, r *http.Request) {
+	var req RefundRequest
+
+	err := request.DecodeJSON(w, r, &req)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Failed to decode JSON",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	params := mux.Vars(r)
+	req.TransactionID = params["transactionId"]
+	req.StaffID, _ = r.Context().Value(middleware.ContextAuthKey{}).(string)
+
+	refund, err := h.service.RefundCheckout(r.Context(), req)
+	if errors.Is(err, ErrCheckoutNotFound) {
+		response.JSON(w, http.StatusNotFound, response.ResponseBody{
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, ErrValidationFailed) ||
+		errors.Is(err, ErrCheckoutVoided) ||
+		errors.Is(err, ErrProductNotInCheckout) ||
+		errors.Is(err, ErrRefundExceedsSold) ||
+		errors.Is(err, ErrRefundMethodNotPaid) ||
+		errors.Is(err, ErrRefundExceedsPaid) {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	response.JSON(w, http.StatusCreated, response.ResponseBody{
+		Message: "Refund created successfully",
+		Data:    refund,
+	})
+}
+
+func (h *Handler) VoidCheckout(w http.ResponseWriter, r *http.Request) {
+	var req VoidRequest
+
+	params := mux.Vars(r)
+	req.TransactionID = params["transactionId"]
+	req.StaffID, _ = r.Context().Value(middleware.ContextAuthKey{}).(string)
+
+	err := h.service.VoidCheckout(r.Context(), req)
+	if errors.Is(err, ErrCheckoutNotFound) {
+		response.JSON(w, http.StatusNotFound, response.ResponseBody{
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, ErrVoidForbidden) {
+		response.JSON(w, http.StatusForbidden, response.ResponseBody{
+			Message: "Forbidden",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, ErrValidationFailed) ||
+		errors.Is(err, ErrCheckoutVoided) ||
+		errors.Is(err, ErrCheckoutRefunded) ||
+		errors.Is(err, ErrVoidWindowExpired) {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "Checkout voided successfully",
+	})
+}
+
+func (h *Handler) GetReceipt(w http.ResponseWriter, r *http.Request) {
+	format, err := NegotiateReceiptFormat(r.URL.Query().Get("format"), r.Header.Get("Accept"))
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	receipt, err := h.service.GetReceipt(r.Context(), mux.Vars(r)["transactionId"])
+	if errors.Is(err, ErrCheckoutNotFound) {
+		response.JSON(w, http.StatusNotFound, response.ResponseBody{
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	body, err := receipt.Render(format)
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	w.Header().Set("Content-Type", format.ContentType())
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+func (h *Handler) ParkSale(w http.ResponseWriter, r *http.Request) {
+	var req ParkSaleRequest
+
+	err := request.DecodeJSON(w, r, &req)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Failed to decode JSON",
+			Error:   err.Error(),
+		})
+		return
+	}
+	req.StaffID, _ = r.Context().Value(middleware.ContextAuthKey{}).(string)
+
+	parkedSale, err := h.service.ParkSale(r.Context(), req)
+	if errors.Is(err, ErrCustomerNotFound) {
+		response.JSON(w, http.StatusNotFound, response.ResponseBody{
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, ErrValidationFailed) {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	response.JSON(w, http.StatusCreated, response.ResponseBody{
+		Message: "Sale parked successfully",
+		Data:    parkedSale,
+	})
+}
+
+func (h *Handler) GetParkedSale(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	parkedSale, err := h.service.GetParkedSale(r.Context(), params["id"])
+	if errors.Is(err, ErrParkedSaleNotFound) {
+		response.JSON(w, http.StatusNotFound, response.ResponseBody{
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "success",
+		Data:    parkedSale,
+	})
+}
+
+func (h *Handler) ListParkedSales(w http.ResponseWriter, r *http.Request) {
+	newSchema := schema.NewDecoder()
+	newSchema.IgnoreUnknownKeys(true)
+
+	var req ListParkedSalesPayload
+	if err := newSchema.Decode(&req, r.URL.Query()); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{})
+		return
+	}
+
+	parkedSales, err := h.service.ListParkedSales(r.Context(), req)
+	if errors.Is(err, ErrValidationFailed) {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "success",
+		Data:    parkedSales,
+	})
+}
+
+func (h *Handler) DeleteParkedSale(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+
+	err := h.service.DeleteParkedSale(r.Context(), params["id"])
+	if errors.Is(err, ErrParkedSaleNotFound) {
+		response.JSON(w, http.StatusNotFound, response.ResponseBody{
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "Parked sale deleted successfully",
 	})
 }
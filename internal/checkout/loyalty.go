@@ -0,0 +1,101 @@
+package checkout
+
+import (
+	"github.com/citadel-corp/eniqilo-store/internal/common/id"
+	"github.com/citadel-corp/eniqilo-store/internal/loyalty"
+)
+
+// redeemedPoints returns the loyalty points spent by payments.
+func redeemedPoints(payments []Payment) (int, error) {
+	var points int
+	for _, payment := range payments {
+		if payment.Method != PaymentPoints {
+			continue
+		}
+		p, err := loyalty.ToPoints(int64(payment.Amount))
+		if err != nil {
+			return 0, err
+		}
+		points += p
+	}
+	return points, nil
+}
+
+// earnedPoints returns the loyalty points earned over all lines of a checkout.
+func earnedPoints(productDetails ProductDetails) int {
+	var points int
+	for _, productDetail := range productDetails {
+		points += productDetail.Points
+	}
+	return points
+}
+
+// earnsPoints reports whether points are earned on what was paid with method.
+// Nothing is earned on what was paid with points, gift cards, store credit or
+// vouchers.
+func earnsPoints(method PaymentMethod) bool {
+	return method == PaymentCash || method == PaymentCard || method == PaymentEWallet
+}
+
+// scaleEarnedPoints cuts the points earned on each line down to the share of
+// total that payments paid with methods that earn points.
+func scaleEarnedPoints(productDetails ProductDetails, payments []Payment, total int64) {
+	var notEarning int64
+	for _, payment := range payments {
+		if !earnsPoints(payment.Method) {
+			notEarning += int64(payment.Amount)
+		}
+	}
+	if notEarning == 0 || total <= 0 {
+		return
+	}
+	earning := max(total-notEarning, 0)
+	for i := range productDetails {
+		productDetails[i].Points = int(int64(productDetails[i].Points) * earning / total)
+	}
+}
+
+// checkoutLoyaltyEntries takes the redeemed points off the customer's balance
+// and adds the points earned on ch.
+func checkoutLoyaltyEntries(ch *CheckoutHistory, redeemed int) []loyalty.Entry {
+	entries := make([]loyalty.Entry, 0, 2)
+	if redeemed > 0 {
+		entries = append(entries, newLoyaltyEntry(ch.UserID, &ch.ID, nil, loyalty.EntryRedeem, -redeemed))
+	}
+	if earned := earnedPoints(ch.ProductDetails); earned > 0 {
+		entries = append(entries, newLoyaltyEntry(ch.UserID, &ch.ID, nil, loyalty.EntryEarn, earned))
+	}
+	return entries
+}
+
+// voidLoyaltyEntries undoes checkoutLoyaltyEntries for a voided checkout.
+func voidLoyaltyEntries(ch *CheckoutHistory, redeemed int) []loyalty.Entry {
+	points := redeemed - earnedPoints(ch.ProductDetails)
+	if points == 0 {
+		return nil
+	}
+	return []loyalty.Entry{newLoyaltyEntry(ch.UserID, &ch.ID, nil, loyalty.EntryReversal, points)}
+}
+
+// refundLoyaltyEntries claws back the points earned on the refunded lines.
+func refundLoyaltyEntries(ch *CheckoutHistory, refund *Refund) []loyalty.Entry {
+	var points int
+	for _, refundDetail := range refund.ProductDetails {
+		points += refundDetail.Points
+	}
+	if points == 0 {
+		return nil
+	}
+	return []loyalty.Entry{newLoyaltyEntry(ch.UserID, &ch.ID, &refund.ID, loyalty.EntryClawback, -points)}
+}
+
+func newLoyaltyEntry(userID string, checkoutHistoryID, refundID *string, entryType loyalty.EntryType, points int) loyalty.Entry {
+	return loyalty.Entry{
+		ID:                id.GenerateStringID(16),
+		UserID:            userID,
+		CheckoutHistoryID: checkoutHistoryID,
+		RefundID:          refundID,
+		Type:              entryType,
+		Points:            points,
+	}
+}
@@ -0,0 +1,43 @@
+package checkout
+
+import "testing"
+
+func TestScaleEarnedPoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		payments []Payment
+		want     []int
+	}{
+		{
+			name:     "cash and card earn in full",
+			payments: []Payment{{Method: PaymentCash, Amount: 600}, {Method: PaymentCard, Amount: 400}},
+			want:     []int{30, 7},
+		},
+		{
+			name:     "half paid with a gift card earns half",
+			payments: []Payment{{Method: PaymentGiftCard, Amount: 500}, {Method: PaymentEWallet, Amount: 500}},
+			want:     []int{15, 3},
+		},
+		{
+			name:     "points and store credit earn nothing",
+			payments: []Payment{{Method: PaymentPoints, Amount: 700}, {Method: PaymentStoreCredit, Amount: 300}},
+			want:     []int{0, 0},
+		},
+		{
+			name:     "change on cash does not count against earning",
+			payments: []Payment{{Method: PaymentVoucher, Amount: 250}, {Method: PaymentCash, Amount: 2000}},
+			want:     []int{22, 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			productDetails := ProductDetails{{ProductID: "p1", Points: 30}, {ProductID: "p2", Points: 7}}
+			scaleEarnedPoints(productDetails, tt.payments, 1000)
+			for i, productDetail := range productDetails {
+				if productDetail.Points != tt.want[i] {
+					t.Errorf("line %d earned %d points, want %d", i, productDetail.Points, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package checkout
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PaymentMethod string
+
+var (
+	PaymentCash    PaymentMethod = "Cash"
+	PaymentCard    PaymentMethod = "Card"
+	PaymentEWallet PaymentMethod = "EWallet"
+	PaymentVoucher PaymentMethod = "Voucher"
+	// PaymentPoints redeems the customer's loyalty points for the amount.
+	PaymentPoints PaymentMethod = "Points"
+	// PaymentGiftCard redeems the gift card whose code is the reference.
+	PaymentGiftCard PaymentMethod = "GiftCard"
+	// PaymentStoreCredit redeems the customer's store credit.
+	PaymentStoreCredit PaymentMethod = "StoreCredit"
+)
+
+var PaymentMethods = []interface{}{PaymentCash, PaymentCard, PaymentEWallet, PaymentVoucher, PaymentPoints, PaymentGiftCard, PaymentStoreCredit}
+
+// RefundMethods are the payment methods a refund can be paid out with. What was
+// paid otherwise can only be refunded to store credit.
+var RefundMethods = []interface{}{PaymentCash, PaymentCard, PaymentEWallet}
+
+type Payment struct {
+	ID                string
+	CheckoutHistoryID string
+	Method            PaymentMethod
+	Amount            int
+	Reference         string
+	CreatedAt         time.Time
+}
+
+var (
+	cashRoundingStr      = os.Getenv("CASH_ROUNDING")
+	cashDenominationsStr = os.Getenv("CASH_DENOMINATIONS")
+)
+
+// cashRounding returns the smallest amount that can be paid in cash, set by
+// CASH_ROUNDING. Without it cash is not rounded.
+func cashRounding() int64 {
+	rounding, err := strconv.ParseInt(cashRoundingStr, 10, 64)
+	if err != nil || rounding <= 0 {
+		return 1
+	}
+	return rounding
+}
+
+// roundCash rounds amount to the nearest amount that can be paid in cash.
+func roundCash(amount int64) int64 {
+	rounding := cashRounding()
+	return (amount + rounding/2) / rounding * rounding
+}
+
+// cashDenominations returns the notes and coins change can be given in,
+// largest first. They are set by CASH_DENOMINATIONS as a comma separated list.
+func cashDenominations() []int {
+	denominations := make([]int, 0)
+	for _, s := range strings.Split(cashDenominationsStr, ",") {
+		denomination, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || denomination <= 0 {
+			continue
+		}
+		denominations = append(denominations, denomination)
+	}
+	if len(denominations) == 0 {
+		denominations = []int{100000, 50000, 20000, 10000, 5000, 2000, 1000, 500, 200, 100}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(denominations)))
+	return denominations
+}
+
+// changeBreakdown suggests how to hand out change using as few notes and
+// coins as possible. Any remainder smaller than the smallest denomination is
+// left out.
+func changeBreakdown(change int) []DenominationResponse {
+	breakdown := make([]DenominationResponse, 0)
+	for _, denomination := range cashDenominations() {
+		if count := change / denomination; count > 0 {
+			breakdown = append(breakdown, DenominationResponse{
+				Denomination: denomination,
+				Count:        count,
+			})
+			change -= count * denomination
+		}
+	}
+	return breakdown
+}
+
+// tender checks that payments settle amountDue with the given change. Only
+// cash can be overpaid, so non-cash payments may never add up to more than
+// what is due. Whatever is left for cash to settle is rounded to the nearest
+// amount that can be paid in cash. It returns the total paid and the
+// rounding applied to the amount due.
+func tender(amountDue int64, payments []Payment, change int) (int, int, error) {
+	var paid, nonCash int64
+	var hasCash bool
+	for _, payment := range payments {
+		paid += int64(payment.Amount)
+		if payment.Method == PaymentCash {
+			hasCash = true
+		} else {
+			nonCash += int64(payment.Amount)
+		}
+	}
+	if nonCash > amountDue {
+		return 0, 0, ErrNonCashOverpaid
+	}
+	var rounding int64
+	if hasCash {
+		rounding = roundCash(amountDue-nonCash) - (amountDue - nonCash)
+	}
+	amountDue += rounding
+	if paid < amountDue {
+		return 0, 0, ErrNotEnoughMoney
+	}
+	if paid-amountDue != int64(change) {
+		return 0, 0, ErrWrongChange
+	}
+	return int(paid), int(rounding), nil
+}
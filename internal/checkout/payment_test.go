@@ -0,0 +1,156 @@
+package checkout
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTender(t *testing.T) {
+	tests := []struct {
+		name     string
+		payments []Payment
+		change   int
+		wantPaid int
+		wantErr  error
+	}{
+		{name: "exact cash", payments: []Payment{{Method: PaymentCash, Amount: 1000}}, wantPaid: 1000},
+		{name: "cash with change", payments: []Payment{{Method: PaymentCash, Amount: 2000}}, change: 1000, wantPaid: 2000},
+		{name: "exact card", payments: []Payment{{Method: PaymentCard, Amount: 1000}}, wantPaid: 1000},
+		{
+			name:     "card and cash with change",
+			payments: []Payment{{Method: PaymentCard, Amount: 600}, {Method: PaymentCash, Amount: 500}},
+			change:   100,
+			wantPaid: 1100,
+		},
+		{name: "cash under the amount due", payments: []Payment{{Method: PaymentCash, Amount: 900}}, wantErr: ErrNotEnoughMoney},
+		{
+			name:     "split under the amount due",
+			payments: []Payment{{Method: PaymentEWallet, Amount: 500}, {Method: PaymentCash, Amount: 499}},
+			wantErr:  ErrNotEnoughMoney,
+		},
+		{name: "wrong change", payments: []Payment{{Method: PaymentCash, Amount: 2000}}, change: 500, wantErr: ErrWrongChange},
+		{name: "change on an exact payment", payments: []Payment{{Method: PaymentCash, Amount: 1000}}, change: 100, wantErr: ErrWrongChange},
+		{name: "card over the amount due", payments: []Payment{{Method: PaymentCard, Amount: 1200}}, change: 200, wantErr: ErrNonCashOverpaid},
+		{
+			name:     "non-cash methods together over the amount due",
+			payments: []Payment{{Method: PaymentGiftCard, Amount: 700}, {Method: PaymentPoints, Amount: 400}},
+			wantErr:  ErrNonCashOverpaid,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paid, rounding, err := tender(1000, tt.payments, tt.change)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if paid != tt.wantPaid || rounding != 0 {
+				t.Errorf("tender() = %d, %d; want %d, 0", paid, rounding, tt.wantPaid)
+			}
+		})
+	}
+}
+
+func TestRoundCash(t *testing.T) {
+	defer func(s string) { cashRoundingStr = s }(cashRoundingStr)
+
+	cashRoundingStr = ""
+	if got := roundCash(1049); got != 1049 {
+		t.Errorf("without CASH_ROUNDING: roundCash(1049) = %d, want 1049", got)
+	}
+
+	cashRoundingStr = "100"
+	for amount, want := range map[int64]int64{0: 0, 49: 0, 50: 100, 1000: 1000, 1049: 1000, 1050: 1100, 1099: 1100} {
+		if got := roundCash(amount); got != want {
+			t.Errorf("roundCash(%d) = %d, want %d", amount, got, want)
+		}
+	}
+}
+
+func TestTenderRoundsTheCashPart(t *testing.T) {
+	defer func(s string) { cashRoundingStr = s }(cashRoundingStr)
+	cashRoundingStr = "100"
+
+	tests := []struct {
+		name         string
+		payments     []Payment
+		change       int
+		wantPaid     int
+		wantRounding int
+		wantErr      error
+	}{
+		{name: "cash rounds down", payments: []Payment{{Method: PaymentCash, Amount: 1000}}, wantPaid: 1000, wantRounding: -49},
+		{name: "cash rounded down is still under", payments: []Payment{{Method: PaymentCash, Amount: 900}}, wantErr: ErrNotEnoughMoney},
+		{name: "card is not rounded", payments: []Payment{{Method: PaymentCard, Amount: 1049}}, wantPaid: 1049},
+		{
+			name:     "only what is left for cash is rounded",
+			payments: []Payment{{Method: PaymentCard, Amount: 549}, {Method: PaymentCash, Amount: 500}},
+			wantPaid: 1049,
+		},
+		{
+			name:         "change is due on the rounded amount",
+			payments:     []Payment{{Method: PaymentCard, Amount: 500}, {Method: PaymentCash, Amount: 600}},
+			change:       100,
+			wantPaid:     1100,
+			wantRounding: -49,
+		},
+		{
+			name:     "change on the unrounded amount is wrong",
+			payments: []Payment{{Method: PaymentCard, Amount: 500}, {Method: PaymentCash, Amount: 600}},
+			change:   51,
+			wantErr:  ErrWrongChange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paid, rounding, err := tender(1049, tt.payments, tt.change)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if paid != tt.wantPaid || rounding != tt.wantRounding {
+				t.Errorf("tender() = %d, %d; want %d, %d", paid, rounding, tt.wantPaid, tt.wantRounding)
+			}
+		})
+	}
+}
+
+func TestChangeBreakdown(t *testing.T) {
+	defer func(s string) { cashDenominationsStr = s }(cashDenominationsStr)
+
+	cashDenominationsStr = ""
+	assertBreakdown(t, changeBreakdown(0), nil)
+	assertBreakdown(t, changeBreakdown(18700), []DenominationResponse{
+		{Denomination: 10000, Count: 1},
+		{Denomination: 5000, Count: 1},
+		{Denomination: 2000, Count: 1},
+		{Denomination: 1000, Count: 1},
+		{Denomination: 500, Count: 1},
+		{Denomination: 200, Count: 1},
+	})
+	// 50 is smaller than the smallest coin and is left out
+	assertBreakdown(t, changeBreakdown(250150), []DenominationResponse{
+		{Denomination: 100000, Count: 2},
+		{Denomination: 50000, Count: 1},
+		{Denomination: 100, Count: 1},
+	})
+
+	// denominations are sorted, and ones that are not positive numbers skipped
+	cashDenominationsStr = "5, 20,x,-10,10"
+	assertBreakdown(t, changeBreakdown(45), []DenominationResponse{
+		{Denomination: 20, Count: 2},
+		{Denomination: 5, Count: 1},
+	})
+}
+
+func assertBreakdown(t *testing.T, got, want []DenominationResponse) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("breakdown = %v, want %v", got, want)
+		return
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("breakdown = %v, want %v", got, want)
+			return
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package checkout
+
+import (
+	"github.com/citadel-corp/eniqilo-store/internal/loyalty"
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+	"github.com/citadel-corp/eniqilo-store/internal/promotion"
+	"github.com/citadel-corp/eniqilo-store/internal/tax"
+)
+
+// Quote is the priced form of a basket. It is built from the same product
+// rows that a checkout would deduct from, so a quote and the checkout that
+// follows it agree on every total.
+type Quote struct {
+	Lines        []QuoteLine
+	Subtotal     int64
+	Discount     int64
+	Tax          int64
+	TaxInclusive bool
+	Total        int64
+	Points       int
+}
+
+type QuoteLine struct {
+	ProductDetail
+	// Problem is set when the line cannot be checked out as is.
+	Problem error
+}
+
+// Err returns the first line problem, if any.
+func (q *Quote) Err() error {
+	for _, line := range q.Lines {
+		if line.Problem != nil {
+			return line.Problem
+		}
+	}
+	return nil
+}
+
+// ProductDetails returns the priced lines of q.
+func (q *Quote) ProductDetails() ProductDetails {
+	productDetails := make(ProductDetails, len(q.Lines))
+	for i, line := range q.Lines {
+		productDetails[i] = line.ProductDetail
+	}
+	return productDetails
+}
+
+// pricingRules are the promotions and taxes in effect for a basket.
+type pricingRules struct {
+	promotions   []*promotion.Promotion
+	taxRates     *tax.Rates
+	taxInclusive bool
+	loyalty      *loyalty.Rules
+}
+
+func buildQuote(productDetails []ProductDetail, products []*product.Product, locationID string, rules *pricingRules) *Quote {
+	productByID := make(map[string]*product.Product, len(products))
+	for _, product := range products {
+		productByID[product.ID] = product
+	}
+	// the same product may appear on several lines, so stock is checked
+	// against the quantity requested over the whole basket
+	requested := make(map[string]int, len(productDetails))
+	for _, productDetail := range productDetails {
+		requested[productDetail.ProductID] += productDetail.Quantity
+	}
+
+	q := &Quote{Lines: make([]QuoteLine, len(productDetails)), TaxInclusive: rules.taxInclusive}
+	for i, productDetail := range productDetails {
+		line := QuoteLine{ProductDetail: productDetail}
+		product, ok := productByID[productDetail.ProductID]
+		if !ok {
+			line.Problem = ErrProductNotFound
+			q.Lines[i] = line
+			continue
+		}
+		line.Name = product.Name
+		line.SKU = product.SKU
+		line.Category = product.Category
+		line.Price = product.Price
+		if p, discount := promotion.Best(rules.promotions, product.ID, product.Category, product.Price, productDetail.Quantity); p != nil {
+			line.PromotionID = p.ID
+			line.Discount = discount
+		}
+		line.Total = product.Price*int64(productDetail.Quantity) - line.Discount
+		line.TaxRate = rules.taxRates.Rate(product.ID, product.Category)
+		line.Tax = tax.Compute(line.Total, line.TaxRate, rules.taxInclusive)
+		if !rules.taxInclusive {
+			line.Total += line.Tax
+		}
+		// points are earned on what was spent before tax
+		line.Points = rules.loyalty.Points(line.Total-line.Tax, product.Category)
+		if !product.IsAvailable {
+			line.Problem = ErrProductUnavailable
+		} else if product.StockAt(locationID) < requested[product.ID] {
+			line.Problem = ErrProductStockNotEnough
+		}
+		q.Subtotal += line.Subtotal()
+		q.Discount += line.Discount
+		q.Tax += line.Tax
+		q.Total += line.Total
+		q.Points += line.Points
+		q.Lines[i] = line
+	}
+	return q
+}
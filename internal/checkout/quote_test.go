@@ -0,0 +1,122 @@
+package checkout
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/citadel-corp/eniqilo-store/internal/loyalty"
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+	"github.com/citadel-corp/eniqilo-store/internal/promotion"
+	"github.com/citadel-corp/eniqilo-store/internal/tax"
+)
+
+func newQuoteProduct(id string, price int64, stock int) *product.Product {
+	return &product.Product{
+		ID:          id,
+		Name:        "Product " + id,
+		SKU:         "SKU-" + id,
+		Category:    product.CategoryClothing,
+		Price:       price,
+		Stock:       stock,
+		Locations:   product.LocationStocks{{LocationID: "store", Stock: stock}},
+		IsAvailable: true,
+	}
+}
+
+func newPricingRules(promotions []*promotion.Promotion, taxInclusive bool) *pricingRules {
+	category := product.CategoryClothing
+	return &pricingRules{
+		promotions:   promotions,
+		taxRates:     tax.NewRates([]*tax.TaxRate{{Category: &category, Rate: 1100}}),
+		taxInclusive: taxInclusive,
+		loyalty:      loyalty.NewRules(nil),
+	}
+}
+
+func TestBuildQuoteTax(t *testing.T) {
+	tests := []struct {
+		name       string
+		inclusive  bool
+		wantTax    int64
+		wantTotal  int64
+		wantPoints int
+	}{
+		// 11% is added on top of the 20000 charged
+		{name: "exclusive", inclusive: false, wantTax: 2200, wantTotal: 22200, wantPoints: 20},
+		// 20000 already holds 20000 * 1100 / 11100 of tax, rounded down
+		{name: "inclusive", inclusive: true, wantTax: 1981, wantTotal: 20000, wantPoints: 18},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := buildQuote(
+				[]ProductDetail{{ProductID: "p1", Quantity: 2}},
+				[]*product.Product{newQuoteProduct("p1", 10000, 5)},
+				"store", newPricingRules(nil, tt.inclusive),
+			)
+			if err := q.Err(); err != nil {
+				t.Fatal(err)
+			}
+			line := q.Lines[0]
+			if line.TaxRate != 1100 || line.Tax != tt.wantTax || line.Total != tt.wantTotal || line.Points != tt.wantPoints {
+				t.Errorf("line rate %d, tax %d, total %d, points %d; want 1100, %d, %d, %d",
+					line.TaxRate, line.Tax, line.Total, line.Points, tt.wantTax, tt.wantTotal, tt.wantPoints)
+			}
+			if q.Subtotal != 20000 || q.Tax != tt.wantTax || q.Total != tt.wantTotal || q.TaxInclusive != tt.inclusive {
+				t.Errorf("quote subtotal %d, tax %d, total %d, inclusive %v", q.Subtotal, q.Tax, q.Total, q.TaxInclusive)
+			}
+		})
+	}
+}
+
+func TestBuildQuotePicksBestPromotion(t *testing.T) {
+	promotions := []*promotion.Promotion{
+		{ID: "twenty", Type: promotion.TypePercentage, Percentage: 20},
+		{ID: "b2g1", Type: promotion.TypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1},
+	}
+	rules := newPricingRules(promotions, true)
+	rules.taxRates = tax.NewRates(nil)
+
+	// 7 units at 1000 are two free units under buy 2 get 1, worth more than
+	// 20% off
+	q := buildQuote(
+		[]ProductDetail{{ProductID: "p1", Quantity: 7}},
+		[]*product.Product{newQuoteProduct("p1", 1000, 10)},
+		"store", rules,
+	)
+	line := q.Lines[0]
+	if line.PromotionID != "b2g1" || line.Discount != 2000 || line.Total != 5000 {
+		t.Errorf("line promotion %q, discount %d, total %d; want b2g1, 2000, 5000", line.PromotionID, line.Discount, line.Total)
+	}
+	if q.Subtotal != 7000 || q.Discount != 2000 || q.Total != 5000 {
+		t.Errorf("quote subtotal %d, discount %d, total %d; want 7000, 2000, 5000", q.Subtotal, q.Discount, q.Total)
+	}
+}
+
+func TestBuildQuoteFlagsLines(t *testing.T) {
+	unavailable := newQuoteProduct("p2", 1000, 10)
+	unavailable.IsAvailable = false
+	products := []*product.Product{newQuoteProduct("p1", 1000, 5), unavailable}
+
+	q := buildQuote([]ProductDetail{
+		{ProductID: "p1", Quantity: 3},
+		{ProductID: "p2", Quantity: 1},
+		{ProductID: "p3", Quantity: 1},
+		// 3 more of p1 are more than the 5 in stock over the whole basket
+		{ProductID: "p1", Quantity: 3},
+	}, products, "store", newPricingRules(nil, false))
+
+	want := []error{ErrProductStockNotEnough, ErrProductUnavailable, ErrProductNotFound, ErrProductStockNotEnough}
+	for i, line := range q.Lines {
+		if !errors.Is(line.Problem, want[i]) {
+			t.Errorf("line %d problem = %v, want %v", i, line.Problem, want[i])
+		}
+	}
+	if !errors.Is(q.Err(), ErrProductStockNotEnough) {
+		t.Errorf("Err() = %v, want the first line problem", q.Err())
+	}
+
+	q = buildQuote([]ProductDetail{{ProductID: "p1", Quantity: 5}}, products, "elsewhere", newPricingRules(nil, false))
+	if !errors.Is(q.Err(), ErrProductStockNotEnough) {
+		t.Errorf("stock at another location: Err() = %v, want %v", q.Err(), ErrProductStockNotEnough)
+	}
+}
@@ -0,0 +1,298 @@
+package checkout
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ReceiptFormat string
+
+var (
+	ReceiptText   ReceiptFormat = "text"
+	ReceiptHTML   ReceiptFormat = "html"
+	ReceiptESCPOS ReceiptFormat = "escpos"
+)
+
+var ReceiptFormats = []interface{}{ReceiptText, ReceiptHTML, ReceiptESCPOS}
+
+// ContentType returns the media type a receipt in format f is served as.
+func (f ReceiptFormat) ContentType() string {
+	switch f {
+	case ReceiptHTML:
+		return "text/html; charset=utf-8"
+	case ReceiptESCPOS:
+		return "application/vnd.escpos"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// NegotiateReceiptFormat picks the receipt format from an explicit format
+// name, falling back to the Accept header and then plain text.
+func NegotiateReceiptFormat(format string, accept string) (ReceiptFormat, error) {
+	if format != "" {
+		for _, f := range ReceiptFormats {
+			if f == ReceiptFormat(format) {
+				return ReceiptFormat(format), nil
+			}
+		}
+		return "", ErrUnknownReceiptFormat
+	}
+	switch {
+	case strings.Contains(accept, "application/vnd.escpos"),
+		strings.Contains(accept, "application/octet-stream"):
+		return ReceiptESCPOS, nil
+	case strings.Contains(accept, "text/html"):
+		return ReceiptHTML, nil
+	}
+	return ReceiptText, nil
+}
+
+var (
+	receiptHeader = os.Getenv("RECEIPT_HEADER")
+	receiptFooter = os.Getenv("RECEIPT_FOOTER")
+)
+
+// receiptWidth is the number of characters per line on a 80mm thermal
+// printer using the default font.
+const receiptWidth = 42
+
+// Receipt is a rendered view of a checkout. Header and Footer hold the store
+// lines printed above and below the sale.
+type Receipt struct {
+	Header        []string
+	Footer        []string
+	TransactionID string
+	StaffName     string
+	CustomerName  string
+	Lines         ProductDetails
+	Subtotal      int64
+	Discount      int64
+	Tax           int64
+	TaxInclusive  bool
+	Total         int64
+	Payments      []Payment
+	Change        int
+	Rounding      int
+	PointsEarned  int
+	VoidedAt      *time.Time
+	CreatedAt     time.Time
+}
+
+func newReceipt(ch *CheckoutHistory, payments []Payment) *Receipt {
+	r := &Receipt{
+		Header:        receiptLines(receiptHeader),
+		Footer:        receiptLines(receiptFooter),
+		TransactionID: ch.ID,
+		Lines:         ch.ProductDetails,
+		Tax:           ch.Tax,
+		TaxInclusive:  ch.TaxInclusive,
+		Payments:      payments,
+		Change:        ch.Change,
+		Rounding:      ch.Rounding,
+		PointsEarned:  earnedPoints(ch.ProductDetails),
+		VoidedAt:      ch.VoidedAt,
+		CreatedAt:     ch.CreatedAt,
+	}
+	for _, line := range ch.ProductDetails {
+		r.Subtotal += line.Subtotal()
+		r.Discount += line.Discount
+		r.Total += line.Total
+	}
+	return r
+}
+
+func receiptLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(s, `\n`, "\n"), "\n")
+}
+
+// RoundedTotal returns the total after cash rounding.
+func (r *Receipt) RoundedTotal() int64 {
+	return r.Total + int64(r.Rounding)
+}
+
+// Render renders the receipt in the given format.
+func (r *Receipt) Render(format ReceiptFormat) ([]byte, error) {
+	switch format {
+	case ReceiptText:
+		return []byte(r.text()), nil
+	case ReceiptHTML:
+		return r.html()
+	case ReceiptESCPOS:
+		return r.escpos(), nil
+	}
+	return nil, ErrUnknownReceiptFormat
+}
+
+// text renders the receipt as fixed width plain text.
+func (r *Receipt) text() string {
+	var b strings.Builder
+	for _, line := range r.Header {
+		b.WriteString(center(line) + "\n")
+	}
+	if len(r.Header) > 0 {
+		b.WriteString(strings.Repeat("=", receiptWidth) + "\n")
+	}
+	r.writeBody(&b)
+	if len(r.Footer) > 0 {
+		b.WriteString(strings.Repeat("=", receiptWidth) + "\n")
+	}
+	for _, line := range r.Footer {
+		b.WriteString(center(line) + "\n")
+	}
+	return b.String()
+}
+
+func (r *Receipt) writeBody(b *strings.Builder) {
+	if r.VoidedAt != nil {
+		b.WriteString(center("*** VOID ***") + "\n")
+	}
+	b.WriteString("No   : " + r.TransactionID + "\n")
+	b.WriteString("Date : " + r.CreatedAt.Format("2006-01-02 15:04") + "\n")
+	if r.StaffName != "" {
+		b.WriteString("Staff: " + r.StaffName + "\n")
+	}
+	if r.CustomerName != "" {
+		b.WriteString("Cust : " + r.CustomerName + "\n")
+	}
+	b.WriteString(strings.Repeat("-", receiptWidth) + "\n")
+	for _, line := range r.Lines {
+		b.WriteString(line.Name + "\n")
+		b.WriteString(columns(fmt.Sprintf("  %d x %d", line.Quantity, line.Price), amount(line.Subtotal())) + "\n")
+		if line.Discount > 0 {
+			b.WriteString(columns("  Discount", amount(-line.Discount)) + "\n")
+		}
+	}
+	b.WriteString(strings.Repeat("-", receiptWidth) + "\n")
+	b.WriteString(columns("Subtotal", amount(r.Subtotal)) + "\n")
+	if r.Discount > 0 {
+		b.WriteString(columns("Discount", amount(-r.Discount)) + "\n")
+	}
+	if r.TaxInclusive {
+		b.WriteString(columns("Tax (included)", amount(r.Tax)) + "\n")
+	} else {
+		b.WriteString(columns("Tax", amount(r.Tax)) + "\n")
+	}
+	if r.Rounding != 0 {
+		b.WriteString(columns("Rounding", amount(int64(r.Rounding))) + "\n")
+	}
+	b.WriteString(columns("TOTAL", amount(r.RoundedTotal())) + "\n")
+	for _, payment := range r.Payments {
+		b.WriteString(columns(string(payment.Method), amount(int64(payment.Amount))) + "\n")
+	}
+	b.WriteString(columns("Change", amount(int64(r.Change))) + "\n")
+	if r.PointsEarned > 0 {
+		b.WriteString(columns("Points earned", strconv.Itoa(r.PointsEarned)) + "\n")
+	}
+}
+
+var receiptTemplate = template.Must(template.New("receipt").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Receipt {{.TransactionID}}</title>
+<style>
+body { font-family: monospace; max-width: 42ch; margin: 0 auto; }
+table { width: 100%; border-collapse: collapse; }
+td.amount { text-align: right; }
+.center { text-align: center; }
+</style>
+</head>
+<body>
+{{range .Header}}<div class="center">{{.}}</div>
+{{end}}{{if .VoidedAt}}<h2 class="center">VOID</h2>
+{{end}}<p>No: {{.TransactionID}}<br>Date: {{.CreatedAt.Format "2006-01-02 15:04"}}{{if .StaffName}}<br>Staff: {{.StaffName}}{{end}}{{if .CustomerName}}<br>Customer: {{.CustomerName}}{{end}}</p>
+<table>
+{{range .Lines}}<tr><td colspan="2">{{.Name}}</td></tr>
+<tr><td>{{.Quantity}} x {{.Price}}</td><td class="amount">{{.Subtotal}}</td></tr>
+{{if .Discount}}<tr><td>Discount</td><td class="amount">-{{.Discount}}</td></tr>
+{{end}}{{end}}</table>
+<hr>
+<table>
+<tr><td>Subtotal</td><td class="amount">{{.Subtotal}}</td></tr>
+{{if .Discount}}<tr><td>Discount</td><td class="amount">-{{.Discount}}</td></tr>
+{{end}}<tr><td>Tax{{if .TaxInclusive}} (included){{end}}</td><td class="amount">{{.Tax}}</td></tr>
+{{if .Rounding}}<tr><td>Rounding</td><td class="amount">{{.Rounding}}</td></tr>
+{{end}}<tr><th align="left">TOTAL</th><th align="right">{{.RoundedTotal}}</th></tr>
+{{range .Payments}}<tr><td>{{.Method}}</td><td class="amount">{{.Amount}}</td></tr>
+{{end}}<tr><td>Change</td><td class="amount">{{.Change}}</td></tr>
+{{if .PointsEarned}}<tr><td>Points earned</td><td class="amount">{{.PointsEarned}}</td></tr>
+{{end}}</table>
+{{range .Footer}}<div class="center">{{.}}</div>
+{{end}}</body>
+</html>
+`))
+
+func (r *Receipt) html() ([]byte, error) {
+	var b bytes.Buffer
+	err := receiptTemplate.Execute(&b, r)
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// ESC/POS control sequences understood by thermal receipt printers.
+var (
+	escposInit        = []byte{0x1b, '@'}
+	escposAlignLeft   = []byte{0x1b, 'a', 0}
+	escposAlignCenter = []byte{0x1b, 'a', 1}
+	escposBoldOn      = []byte{0x1b, 'E', 1}
+	escposBoldOff     = []byte{0x1b, 'E', 0}
+	escposFeedAndCut  = []byte{0x1d, 'V', 66, 3}
+)
+
+// escpos renders the receipt as raw bytes that can be sent to the printer
+// as is.
+func (r *Receipt) escpos() []byte {
+	var b bytes.Buffer
+	b.Write(escposInit)
+	b.Write(escposAlignCenter)
+	b.Write(escposBoldOn)
+	for _, line := range r.Header {
+		b.WriteString(line + "\n")
+	}
+	b.Write(escposBoldOff)
+	b.Write(escposAlignLeft)
+
+	var body strings.Builder
+	r.writeBody(&body)
+	b.WriteString(body.String())
+
+	b.Write(escposAlignCenter)
+	for _, line := range r.Footer {
+		b.WriteString(line + "\n")
+	}
+	b.Write(escposAlignLeft)
+	b.Write(escposFeedAndCut)
+	return b.Bytes()
+}
+
+func amount(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+// columns lays out left and right on a single receipt line.
+func columns(left, right string) string {
+	pad := receiptWidth - len(left) - len(right)
+	if pad < 1 {
+		pad = 1
+	}
+	return left + strings.Repeat(" ", pad) + right
+}
+
+func center(s string) string {
+	pad := (receiptWidth - len(s)) / 2
+	if pad < 0 {
+		pad = 0
+	}
+	return strings.Repeat(" ", pad) + s
+}
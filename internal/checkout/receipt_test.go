@@ -0,0 +1,32 @@
+package checkout
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNegotiateReceiptFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		accept  string
+		want    ReceiptFormat
+		wantErr error
+	}{
+		{want: ReceiptText},
+		{accept: "*/*", want: ReceiptText},
+		{accept: "text/html,application/xhtml+xml;q=0.9", want: ReceiptHTML},
+		{accept: "application/vnd.escpos", want: ReceiptESCPOS},
+		{accept: "application/octet-stream", want: ReceiptESCPOS},
+		// an explicit format wins over the Accept header
+		{format: "text", accept: "text/html", want: ReceiptText},
+		{format: "escpos", want: ReceiptESCPOS},
+		{format: "pdf", accept: "text/html", wantErr: ErrUnknownReceiptFormat},
+		{format: "HTML", wantErr: ErrUnknownReceiptFormat},
+	}
+	for _, tt := range tests {
+		got, err := NegotiateReceiptFormat(tt.format, tt.accept)
+		if !errors.Is(err, tt.wantErr) || got != tt.want {
+			t.Errorf("NegotiateReceiptFormat(%q, %q) = %q, %v; want %q, %v", tt.format, tt.accept, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package checkout
+
+// prorateRefund prices the lines of refund from what ch charged for them and
+// adds them up into refund.Amount. refunded is the quantity of each product
+// already refunded by earlier refunds of ch.
+func prorateRefund(ch *CheckoutHistory, refund *Refund, refunded map[string]int) error {
+	sold := make(map[string]int, len(ch.ProductDetails))
+	soldTotals := make(map[string]int64, len(ch.ProductDetails))
+	soldTaxes := make(map[string]int64, len(ch.ProductDetails))
+	soldPoints := make(map[string]int64, len(ch.ProductDetails))
+	prices := make(map[string]int64, len(ch.ProductDetails))
+	for _, productDetail := range ch.ProductDetails {
+		sold[productDetail.ProductID] += productDetail.Quantity
+		soldTotals[productDetail.ProductID] += productDetail.Total
+		soldTaxes[productDetail.ProductID] += productDetail.Tax
+		soldPoints[productDetail.ProductID] += int64(productDetail.Points)
+		prices[productDetail.ProductID] = productDetail.Price
+	}
+
+	taken := make(map[string]int, len(refunded))
+	for productID, quantity := range refunded {
+		taken[productID] = quantity
+	}
+	refund.Amount = 0
+	for i := range refund.ProductDetails {
+		refundDetail := &refund.ProductDetails[i]
+		productID := refundDetail.ProductID
+		if _, ok := sold[productID]; !ok {
+			return ErrProductNotInCheckout
+		}
+		if taken[productID]+refundDetail.Quantity > sold[productID] {
+			return ErrRefundExceedsSold
+		}
+		// discounts were applied per line, so the refund is the share of
+		// what was actually charged rather than the list price
+		refundDetail.Price = prices[productID]
+		refundDetail.Total = prorate(soldTotals[productID], sold[productID], taken[productID], refundDetail.Quantity)
+		refundDetail.Tax = prorate(soldTaxes[productID], sold[productID], taken[productID], refundDetail.Quantity)
+		refundDetail.Points = int(prorate(soldPoints[productID], sold[productID], taken[productID], refundDetail.Quantity))
+		taken[productID] += refundDetail.Quantity
+		refund.Amount += refundDetail.Total
+	}
+	return nil
+}
+
+// prorate returns the share of amount for quantity out of sold units, when
+// taken units have already had their share. Shares are cut off cumulatively
+// so that they add up to exactly amount once every unit is taken, with the
+// remainder of the division going to the last share.
+func prorate(amount int64, sold, taken, quantity int) int64 {
+	return amount*int64(taken+quantity)/int64(sold) - amount*int64(taken)/int64(sold)
+}
+
+// refundMethod returns the method amount is paid out with, which is method
+// or, if it is empty, the first of payments that can be paid out. It has to
+// be a method the transaction was paid with, and what was paid with it,
+// less change for cash and less paidOut by earlier refunds, has to cover
+// amount.
+func refundMethod(payments []*Payment, change int, paidOut map[PaymentMethod]int64, method PaymentMethod, amount int64) (PaymentMethod, error) {
+	paid := make(map[PaymentMethod]int64, len(payments))
+	for _, payment := range payments {
+		if payment.Method != PaymentCash && payment.Method != PaymentCard && payment.Method != PaymentEWallet {
+			continue
+		}
+		if method == "" {
+			method = payment.Method
+		}
+		paid[payment.Method] += int64(payment.Amount)
+	}
+	paid[PaymentCash] -= int64(change)
+
+	if paid[method] <= 0 {
+		return "", ErrRefundMethodNotPaid
+	}
+	if paidOut[method]+amount > paid[method] {
+		return "", ErrRefundExceedsPaid
+	}
+	return method, nil
+}
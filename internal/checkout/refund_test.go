@@ -0,0 +1,135 @@
+package checkout
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProrateSharesAddUpToAmount(t *testing.T) {
+	for _, steps := range [][]int{
+		{33, 33, 34},
+		{1, 1, 1},
+		{2, 1},
+		{99, 1},
+	} {
+		sold := 0
+		for _, quantity := range steps {
+			sold += quantity
+		}
+		var refunded int64
+		taken := 0
+		for _, quantity := range steps {
+			refunded += prorate(100, sold, taken, quantity)
+			taken += quantity
+		}
+		if refunded != 100 {
+			t.Errorf("refunding %v of 100 returned %d", steps, refunded)
+		}
+	}
+}
+
+func TestProrateRefundGivesLastRefundTheRemainder(t *testing.T) {
+	ch := &CheckoutHistory{
+		ProductDetails: ProductDetails{
+			{ProductID: "p1", Quantity: 3, Price: 40, Tax: 10, Total: 100, Points: 10},
+		},
+	}
+	refunded := map[string]int{}
+	var totals, taxes int64
+	var points int
+	for i := 0; i < 3; i++ {
+		refund := &Refund{ProductDetails: RefundDetails{{ProductID: "p1", Quantity: 1}}}
+		if err := prorateRefund(ch, refund, refunded); err != nil {
+			t.Fatalf("refund %d: %v", i+1, err)
+		}
+		line := refund.ProductDetails[0]
+		if refund.Amount != line.Total {
+			t.Errorf("refund %d: amount = %d, want line total %d", i+1, refund.Amount, line.Total)
+		}
+		if line.Price != 40 {
+			t.Errorf("refund %d: price = %d, want 40", i+1, line.Price)
+		}
+		totals += line.Total
+		taxes += line.Tax
+		points += line.Points
+		refunded["p1"]++
+	}
+	if totals != 100 || taxes != 10 || points != 10 {
+		t.Errorf("refunded total %d, tax %d, points %d; want 100, 10, 10", totals, taxes, points)
+	}
+}
+
+func TestProrateRefundSameProductTwiceInOneRefund(t *testing.T) {
+	ch := &CheckoutHistory{
+		ProductDetails: ProductDetails{
+			{ProductID: "p1", Quantity: 2, Total: 100},
+			{ProductID: "p1", Quantity: 1, Total: 1},
+		},
+	}
+	refund := &Refund{ProductDetails: RefundDetails{
+		{ProductID: "p1", Quantity: 2},
+		{ProductID: "p1", Quantity: 1, Damaged: true},
+	}}
+	if err := prorateRefund(ch, refund, map[string]int{}); err != nil {
+		t.Fatal(err)
+	}
+	if refund.Amount != 101 {
+		t.Errorf("amount = %d, want 101", refund.Amount)
+	}
+}
+
+func TestProrateRefundRejects(t *testing.T) {
+	ch := &CheckoutHistory{
+		ProductDetails: ProductDetails{{ProductID: "p1", Quantity: 2, Total: 100}},
+	}
+
+	refund := &Refund{ProductDetails: RefundDetails{{ProductID: "p2", Quantity: 1}}}
+	if err := prorateRefund(ch, refund, map[string]int{}); !errors.Is(err, ErrProductNotInCheckout) {
+		t.Errorf("product not sold: err = %v, want %v", err, ErrProductNotInCheckout)
+	}
+
+	refund = &Refund{ProductDetails: RefundDetails{{ProductID: "p1", Quantity: 2}}}
+	if err := prorateRefund(ch, refund, map[string]int{"p1": 1}); !errors.Is(err, ErrRefundExceedsSold) {
+		t.Errorf("more than sold: err = %v, want %v", err, ErrRefundExceedsSold)
+	}
+}
+
+func TestRefundMethod(t *testing.T) {
+	// 150 in cash with 30 change, so 120 was kept in cash
+	split := []*Payment{
+		{Method: PaymentGiftCard, Amount: 50},
+		{Method: PaymentCash, Amount: 150},
+		{Method: PaymentCard, Amount: 80},
+	}
+	tests := []struct {
+		name    string
+		method  PaymentMethod
+		paidOut map[PaymentMethod]int64
+		amount  int64
+		want    PaymentMethod
+		wantErr error
+	}{
+		{name: "defaults to the first method that can be paid out", amount: 100, want: PaymentCash},
+		{name: "cash less change", method: PaymentCash, amount: 120, want: PaymentCash},
+		{name: "cash over what was kept", method: PaymentCash, amount: 121, wantErr: ErrRefundExceedsPaid},
+		{name: "card", method: PaymentCard, amount: 80, want: PaymentCard},
+		{name: "card after an earlier card refund", method: PaymentCard, paidOut: map[PaymentMethod]int64{PaymentCard: 40}, amount: 41, wantErr: ErrRefundExceedsPaid},
+		{name: "method the sale was not paid with", method: PaymentEWallet, amount: 1, wantErr: ErrRefundMethodNotPaid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := refundMethod(split, 30, tt.paidOut, tt.method, tt.amount)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("method = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	giftCardOnly := []*Payment{{Method: PaymentGiftCard, Amount: 100}}
+	if _, err := refundMethod(giftCardOnly, 0, nil, "", 100); !errors.Is(err, ErrRefundMethodNotPaid) {
+		t.Errorf("gift card sale: err = %v, want %v", err, ErrRefundMethodNotPaid)
+	}
+}
@@ -4,14 +4,48 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/citadel-corp/eniqilo-store/internal/common/db"
+	"github.com/citadel-corp/eniqilo-store/internal/giftcard"
+	"github.com/citadel-corp/eniqilo-store/internal/loyalty"
+	"github.com/citadel-corp/eniqilo-store/internal/product"
 )
 
 type Repository interface {
-	CreateCheckoutHistory(ctx context.Context, ch *CheckoutHistory) error
+	CreateCheckoutHistory(ctx context.Context, ch *CheckoutHistory, prepare func(products []*product.Product) error) error
+	GetCheckoutHistory(ctx context.Context, id string) (*CheckoutHistory, error)
 	ListCheckoutHistories(ctx context.Context, req ListCheckoutHistoriesPayload) ([]*CheckoutHistory, error)
+	CountCheckoutHistories(ctx context.Context, req ListCheckoutHistoriesPayload) (int, error)
+	CreateRefund(ctx context.Context, refund *Refund, prepare func(ch *CheckoutHistory, refunded map[string]int, paidOut map[PaymentMethod]int64) error) error
+	ListRefunds(ctx context.Context, checkoutHistoryIDs []string) ([]*Refund, error)
+	ListPayments(ctx context.Context, checkoutHistoryIDs []string) ([]*Payment, error)
+	CreateParkedSale(ctx context.Context, ps *ParkedSale, ttl time.Duration) error
+	GetParkedSale(ctx context.Context, id string) (*ParkedSale, error)
+	ListParkedSales(ctx context.Context, req ListParkedSalesPayload) ([]*ParkedSale, error)
+	DeleteParkedSale(ctx context.Context, id string) error
+	VoidCheckoutHistory(ctx context.Context, id string, voidedBy string, prepare func(ch *CheckoutHistory, refunded map[string]int) error) error
+}
+
+const checkoutHistoryColumns = "id, user_id, COALESCE(staff_id, ''), shift_id, location_id, product_details, paid, change, rounding, tax, tax_inclusive, voided_at, voided_by, void_shift_id, created_at"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCheckoutHistory(row rowScanner) (*CheckoutHistory, error) {
+	ch := &CheckoutHistory{}
+	err := row.Scan(&ch.ID, &ch.UserID, &ch.StaffID, &ch.ShiftID, &ch.LocationID, &ch.ProductDetails, &ch.Paid, &ch.Change, &ch.Rounding, &ch.Tax, &ch.TaxInclusive, &ch.VoidedAt, &ch.VoidedBy, &ch.VoidShiftID,
+		&ch.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 type dbRepository struct {
@@ -23,64 +57,762 @@ func NewRepository(db *db.DB) Repository {
 }
 
 // CreateCheckoutHistory implements Repository.
-func (d *dbRepository) CreateCheckoutHistory(ctx context.Context, ch *CheckoutHistory) error {
+// Products in ch are locked with SELECT ... FOR UPDATE and handed to prepare
+// so that availability, stock and price checks see the same rows that are
+// later decremented. Everything runs in a single transaction.
+func (d *dbRepository) CreateCheckoutHistory(ctx context.Context, ch *CheckoutHistory, prepare func(products []*product.Product) error) error {
 	return d.db.StartTx(ctx, func(tx *sql.Tx) error {
+		productIDs := make([]string, len(ch.ProductDetails))
+		for i, productDetail := range ch.ProductDetails {
+			productIDs[i] = productDetail.ProductID
+		}
+		products, err := lockProducts(ctx, tx, productIDs, ch.LocationID)
+		if err != nil {
+			return err
+		}
+		err = prepare(products)
+		if err != nil {
+			return err
+		}
+
+		if ch.ParkedSaleID != "" {
+			// deleting the parked sale in the same transaction makes sure it
+			// is only ever checked out once
+			err := deleteParkedSale(ctx, tx, ch.ParkedSaleID)
+			if err != nil {
+				return err
+			}
+		}
+		if ch.CartID != "" {
+			// the same goes for a cart, so it cannot be sold twice
+			err := deleteCart(ctx, tx, ch.CartID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = applyStockMovements(ctx, tx, ch.StockMovements)
+		if err != nil {
+			return err
+		}
+		ch.LowStockAlerts, err = markLowStock(ctx, tx, productIDs)
+		if err != nil {
+			return err
+		}
+
 		q := `
-		INSERT INTO checkout_histories (
-			id, user_id, product_details, paid, change
-		) VALUES (
-			$1, $2, $3, $4, $5
-		);
-	`
-		_, err := d.db.DB().ExecContext(ctx, q, ch.ID, ch.UserID, ch.ProductDetails, ch.Paid, ch.Change)
+			INSERT INTO checkout_histories (
+				id, user_id, staff_id, shift_id, location_id, product_details, paid, change, rounding, tax, tax_inclusive
+			) VALUES (
+				$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+			) RETURNING created_at;
+		`
+		err = tx.QueryRowContext(ctx, q, ch.ID, ch.UserID, ch.StaffID, ch.ShiftID, ch.LocationID, ch.ProductDetails, ch.Paid, ch.Change, ch.Rounding, ch.Tax,
+			ch.TaxInclusive).Scan(&ch.CreatedAt)
 		if err != nil {
 			return err
 		}
 
-		for _, productDetail := range ch.ProductDetails {
+		for i := range ch.Payments {
+			payment := &ch.Payments[i]
 			q = `
-				UPDATE products
-				SET stock = stock - $1
-				WHERE id = $2;
+				INSERT INTO checkout_payments (
+					id, checkout_history_id, method, amount, reference
+				) VALUES (
+					$1, $2, $3, $4, $5
+				) RETURNING created_at;
 			`
-			_, err := d.db.DB().ExecContext(ctx, q, productDetail.Quantity, productDetail.ProductID)
+			err := tx.QueryRowContext(ctx, q, payment.ID, ch.ID, payment.Method, payment.Amount, payment.Reference).Scan(&payment.CreatedAt)
 			if err != nil {
 				return err
 			}
+			payment.CheckoutHistoryID = ch.ID
 		}
-		return nil
+		err = applyGiftCardTransactions(ctx, tx, ch.GiftCardTransactions)
+		if err != nil {
+			return err
+		}
+		return applyLoyaltyEntries(ctx, tx, ch.LoyaltyEntries)
 	})
+}
 
+// applyGiftCardTransactions records transactions and moves the cards'
+// balances by them. No balance may go below zero.
+func applyGiftCardTransactions(ctx context.Context, tx *sql.Tx, transactions []giftcard.Transaction) error {
+	for _, transaction := range transactions {
+		q := `
+			UPDATE gift_cards
+			SET balance = balance + $1
+			WHERE id = $2 AND balance + $1 >= 0;
+		`
+		row, err := tx.ExecContext(ctx, q, transaction.Amount, transaction.GiftCardID)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := row.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return ErrGiftCardBalanceLow
+		}
+		err = insertGiftCardTransaction(ctx, tx, &transaction)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// creditStoreCredit adds transaction to the customer's store credit, opening
+// the account if the customer has none yet.
+func creditStoreCredit(ctx context.Context, tx *sql.Tx, customerID string, transaction *giftcard.Transaction) error {
+	q := `
+		INSERT INTO gift_cards (
+			id, customer_id, balance
+		) VALUES (
+			$1, $2, $3
+		)
+		ON CONFLICT (customer_id) WHERE customer_id IS NOT NULL DO UPDATE
+		SET balance = gift_cards.balance + EXCLUDED.balance
+		RETURNING id;
+	`
+	err := tx.QueryRowContext(ctx, q, transaction.GiftCardID, customerID, transaction.Amount).Scan(&transaction.GiftCardID)
+	if err != nil {
+		return err
+	}
+	return insertGiftCardTransaction(ctx, tx, transaction)
+}
+
+func insertGiftCardTransaction(ctx context.Context, tx *sql.Tx, transaction *giftcard.Transaction) error {
+	q := `
+		INSERT INTO gift_card_transactions (
+			id, gift_card_id, checkout_history_id, refund_id, type, amount
+		) VALUES (
+			$1, $2, $3, $4, $5, $6
+		) RETURNING created_at;
+	`
+	return tx.QueryRowContext(ctx, q, transaction.ID, transaction.GiftCardID, transaction.CheckoutHistoryID, transaction.RefundID,
+		transaction.Type, transaction.Amount).Scan(&transaction.CreatedAt)
+}
+
+// applyLoyaltyEntries records entries and moves the customers' points
+// balances by them. Redemptions may not take a balance below zero. Clawbacks
+// and reversals are floored at zero instead, as the customer may already have
+// spent the points, and the entries are cut down to what was taken off.
+func applyLoyaltyEntries(ctx context.Context, tx *sql.Tx, entries []loyalty.Entry) error {
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Points < 0 && entry.Type != loyalty.EntryRedeem {
+			var balance int
+			q := `
+				SELECT loyalty_points
+				FROM users
+				WHERE id = $1
+				FOR UPDATE;
+			`
+			err := tx.QueryRowContext(ctx, q, entry.UserID).Scan(&balance)
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotEnoughPoints
+			}
+			if err != nil {
+				return err
+			}
+			if entry.Points < -balance {
+				entry.Points = -balance
+			}
+			if entry.Points == 0 {
+				continue
+			}
+		}
+
+		q := `
+			UPDATE users
+			SET loyalty_points = loyalty_points + $1
+			WHERE id = $2;
+		`
+		if entry.Type == loyalty.EntryRedeem {
+			q = `
+				UPDATE users
+				SET loyalty_points = loyalty_points + $1
+				WHERE id = $2 AND loyalty_points + $1 >= 0;
+			`
+		}
+		row, err := tx.ExecContext(ctx, q, entry.Points, entry.UserID)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := row.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return ErrNotEnoughPoints
+		}
+
+		q = `
+			INSERT INTO loyalty_entries (
+				id, user_id, checkout_history_id, refund_id, type, points
+			) VALUES (
+				$1, $2, $3, $4, $5, $6
+			);
+		`
+		_, err = tx.ExecContext(ctx, q, entry.ID, entry.UserID, entry.CheckoutHistoryID, entry.RefundID, entry.Type, entry.Points)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyStockMovements moves the products' stock at each movement's location
+// and records the movements. Restocking a product that has since been
+// deleted is skipped.
+func applyStockMovements(ctx context.Context, tx *sql.Tx, movements []product.StockMovement) error {
+	for i := range movements {
+		err := product.MoveStock(ctx, tx, &movements[i])
+		if errors.Is(err, product.ErrProductNotFound) && movements[i].Delta > 0 {
+			continue
+		}
+		if errors.Is(err, product.ErrProductNotFound) || errors.Is(err, product.ErrStockNotEnough) {
+			return ErrProductStockNotEnough
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markLowStock marks the products that are now at or below their reorder
+// point and have not been alerted on yet, and returns their alerts.
+func markLowStock(ctx context.Context, tx *sql.Tx, ids []string) ([]product.LowStockAlert, error) {
+	q := `
+		UPDATE products
+		SET low_stock_alerted_at = current_timestamp
+		WHERE id = ANY($1) AND stock <= reorder_point AND low_stock_alerted_at IS NULL
+		RETURNING id, name, sku, stock, reorder_point, low_stock_alerted_at;
+	`
+	rows, err := tx.QueryContext(ctx, q, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]product.LowStockAlert, 0)
+	for rows.Next() {
+		alert := product.LowStockAlert{}
+		err := rows.Scan(&alert.ProductID, &alert.Name, &alert.SKU, &alert.Stock, &alert.ReorderPoint, &alert.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, alert)
+	}
+	return res, rows.Err()
+}
+
+// lockProducts selects the given products FOR UPDATE, with their Locations
+// holding only the stock at locationID. Rows are locked in id order so that
+// concurrent checkouts over overlapping baskets cannot deadlock.
+func lockProducts(ctx context.Context, tx *sql.Tx, ids []string, locationID string) ([]*product.Product, error) {
+	q := `
+		SELECT id, name, sku, category, image_url, notes, price, stock,
+			COALESCE((SELECT ps.stock FROM product_stocks ps WHERE ps.product_id = products.id AND ps.location_id = $2), 0),
+			damaged_stock, reorder_point, is_available, created_at
+		FROM products
+		WHERE id = ANY($1)
+		ORDER BY id
+		FOR UPDATE;
+	`
+	rows, err := tx.QueryContext(ctx, q, ids, locationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*product.Product, 0, len(ids))
+	for rows.Next() {
+		p := &product.Product{}
+		var locationStock int
+		err := rows.Scan(&p.ID, &p.Name, &p.SKU, &p.Category, &p.ImageURL, &p.Notes, &p.Price, &p.Stock, &locationStock, &p.DamagedStock,
+			&p.ReorderPoint, &p.IsAvailable, &p.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		p.Locations = product.LocationStocks{{LocationID: locationID, Stock: locationStock}}
+		res = append(res, p)
+	}
+	return res, rows.Err()
+}
+
+// GetCheckoutHistory implements Repository.
+func (d *dbRepository) GetCheckoutHistory(ctx context.Context, id string) (*CheckoutHistory, error) {
+	q := `
+		SELECT ` + checkoutHistoryColumns + `
+		FROM checkout_histories
+		WHERE id = $1;
+	`
+	ch, err := scanCheckoutHistory(d.db.DB().QueryRowContext(ctx, q, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCheckoutNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
 }
 
 // ListCheckoutHistories implements Repository.
 func (d *dbRepository) ListCheckoutHistories(ctx context.Context, req ListCheckoutHistoriesPayload) ([]*CheckoutHistory, error) {
 	var query bytes.Buffer
-	_, _ = query.WriteString("SELECT * FROM checkout_histories ")
-	params := make([]interface{}, 0)
-	if req.CustomerID != "" {
-		_, _ = query.WriteString("WHERE user_id = $1 ")
-		params = append(params, req.CustomerID)
+	_, _ = query.WriteString("SELECT " + checkoutHistoryColumns + " FROM checkout_histories ")
+	where, params, err := checkoutHistoriesFilter(req)
+	if err != nil {
+		return nil, err
 	}
+	_, _ = query.WriteString(where)
 	switch req.CreatedAtSearchType {
 	case Ascending:
 		_, _ = query.WriteString(" ORDER BY created_at ASC ")
 	case Descending:
 		_, _ = query.WriteString(" ORDER BY created_at DESC ")
 	}
-	_, _ = query.WriteString(fmt.Sprintf(" LIMIT %d OFFSET %d;", req.Limit, req.Offset))
+	_, _ = query.WriteString(fmt.Sprintf(" LIMIT $%d OFFSET $%d;", len(params)+1, len(params)+2))
+	params = append(params, req.Limit, req.Offset)
 	rows, err := d.db.DB().QueryContext(ctx, query.String(), params...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	res := make([]*CheckoutHistory, 0)
 	for rows.Next() {
-		ch := &CheckoutHistory{}
-		err := rows.Scan(&ch.ID, &ch.UserID, &ch.ProductDetails, &ch.Paid, &ch.Change, &ch.CreatedAt)
+		ch, err := scanCheckoutHistory(rows)
 		if err != nil {
 			return nil, err
 		}
 		res = append(res, ch)
 	}
-	return res, nil
+	return res, rows.Err()
+}
+
+// CountCheckoutHistories implements Repository.
+func (d *dbRepository) CountCheckoutHistories(ctx context.Context, req ListCheckoutHistoriesPayload) (int, error) {
+	where, params, err := checkoutHistoriesFilter(req)
+	if err != nil {
+		return 0, err
+	}
+	var total int
+	err = d.db.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM checkout_histories "+where+";", params...).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// checkoutHistoriesFilter builds the WHERE clause shared by listing and
+// counting checkout histories.
+func checkoutHistoriesFilter(req ListCheckoutHistoriesPayload) (string, []interface{}, error) {
+	conditions := make([]string, 0)
+	params := make([]interface{}, 0)
+	addCondition := func(condition string, param interface{}) {
+		params = append(params, param)
+		conditions = append(conditions, fmt.Sprintf(condition, len(params)))
+	}
+
+	if req.CustomerID != "" {
+		addCondition("user_id = $%d", req.CustomerID)
+	}
+	if req.StaffID != "" {
+		addCondition("staff_id = $%d", req.StaffID)
+	}
+	if req.ProductID != "" {
+		// containment lets the GIN index on product_details answer the lookup
+		contains, err := json.Marshal([]map[string]string{{"ProductID": req.ProductID}})
+		if err != nil {
+			return "", nil, err
+		}
+		addCondition("product_details @> $%d::jsonb", string(contains))
+	}
+	if req.StartDate != "" {
+		addCondition("created_at >= $%d::date", req.StartDate)
+	}
+	if req.EndDate != "" {
+		addCondition("created_at < $%d::date + 1", req.EndDate)
+	}
+	if req.PaidMin != nil {
+		addCondition("paid >= $%d", *req.PaidMin)
+	}
+	if req.PaidMax != nil {
+		addCondition("paid <= $%d", *req.PaidMax)
+	}
+	if v, err := strconv.ParseBool(req.IsVoided); err == nil {
+		if v {
+			conditions = append(conditions, "voided_at IS NOT NULL")
+		} else {
+			conditions = append(conditions, "voided_at IS NULL")
+		}
+	}
+	if len(conditions) == 0 {
+		return "", params, nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND ") + " ", params, nil
+}
+
+// CreateRefund implements Repository.
+// The original checkout is locked and handed to prepare together with the
+// quantities already refunded per product and the amounts already paid out per
+// payment method, so that concurrent refunds of the same transaction cannot
+// refund more than was sold or paid.
+func (d *dbRepository) CreateRefund(ctx context.Context, refund *Refund, prepare func(ch *CheckoutHistory, refunded map[string]int, paidOut map[PaymentMethod]int64) error) error {
+	return d.db.StartTx(ctx, func(tx *sql.Tx) error {
+		ch, err := lockCheckoutHistory(ctx, tx, refund.CheckoutHistoryID)
+		if err != nil {
+			return err
+		}
+		refunded, err := refundedQuantities(ctx, tx, ch.ID)
+		if err != nil {
+			return err
+		}
+		paidOut, err := refundedAmounts(ctx, tx, ch.ID)
+		if err != nil {
+			return err
+		}
+
+		err = prepare(ch, refunded, paidOut)
+		if err != nil {
+			return err
+		}
+
+		for _, refundDetail := range refund.ProductDetails {
+			if !refundDetail.Damaged {
+				continue
+			}
+			q := `
+				UPDATE products
+				SET damaged_stock = damaged_stock + $1
+				WHERE id = $2;
+			`
+			_, err := tx.ExecContext(ctx, q, refundDetail.Quantity, refundDetail.ProductID)
+			if err != nil {
+				return err
+			}
+		}
+		err = applyStockMovements(ctx, tx, refund.StockMovements)
+		if err != nil {
+			return err
+		}
+
+		q := `
+			INSERT INTO checkout_refunds (
+				id, checkout_history_id, shift_id, product_details, amount, store_credit, method
+			) VALUES (
+				$1, $2, $3, $4, $5, $6, NULLIF($7, '')::payment_methods
+			) RETURNING created_at;
+		`
+		err = tx.QueryRowContext(ctx, q, refund.ID, refund.CheckoutHistoryID, refund.ShiftID, refund.ProductDetails, refund.Amount,
+			refund.StoreCredit, refund.Method).Scan(&refund.CreatedAt)
+		if err != nil {
+			return err
+		}
+		if refund.StoreCreditTransaction != nil {
+			err = creditStoreCredit(ctx, tx, ch.UserID, refund.StoreCreditTransaction)
+			if err != nil {
+				return err
+			}
+		}
+		return applyLoyaltyEntries(ctx, tx, refund.LoyaltyEntries)
+	})
+}
+
+// ListRefunds implements Repository.
+func (d *dbRepository) ListRefunds(ctx context.Context, checkoutHistoryIDs []string) ([]*Refund, error) {
+	if len(checkoutHistoryIDs) == 0 {
+		return make([]*Refund, 0), nil
+	}
+	q := `
+		SELECT id, checkout_history_id, product_details, amount, store_credit, COALESCE(method::text, ''), created_at
+		FROM checkout_refunds
+		WHERE checkout_history_id = ANY($1)
+		ORDER BY created_at ASC;
+	`
+	rows, err := d.db.DB().QueryContext(ctx, q, checkoutHistoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*Refund, 0)
+	for rows.Next() {
+		refund := &Refund{}
+		err := rows.Scan(&refund.ID, &refund.CheckoutHistoryID, &refund.ProductDetails, &refund.Amount, &refund.StoreCredit, &refund.Method,
+			&refund.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, refund)
+	}
+	return res, rows.Err()
+}
+
+// ListPayments implements Repository.
+func (d *dbRepository) ListPayments(ctx context.Context, checkoutHistoryIDs []string) ([]*Payment, error) {
+	if len(checkoutHistoryIDs) == 0 {
+		return make([]*Payment, 0), nil
+	}
+	q := `
+		SELECT id, checkout_history_id, method, amount, reference, created_at
+		FROM checkout_payments
+		WHERE checkout_history_id = ANY($1)
+		ORDER BY created_at ASC;
+	`
+	rows, err := d.db.DB().QueryContext(ctx, q, checkoutHistoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*Payment, 0)
+	for rows.Next() {
+		payment := &Payment{}
+		err := rows.Scan(&payment.ID, &payment.CheckoutHistoryID, &payment.Method, &payment.Amount, &payment.Reference, &payment.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, payment)
+	}
+	return res, rows.Err()
+}
+
+// VoidCheckoutHistory implements Repository.
+// The checkout is locked and handed to prepare before its stock is restored
+// and it is marked as voided, all within a single transaction.
+func (d *dbRepository) VoidCheckoutHistory(ctx context.Context, id string, voidedBy string, prepare func(ch *CheckoutHistory, refunded map[string]int) error) error {
+	return d.db.StartTx(ctx, func(tx *sql.Tx) error {
+		ch, err := lockCheckoutHistory(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		refunded, err := refundedQuantities(ctx, tx, ch.ID)
+		if err != nil {
+			return err
+		}
+		err = prepare(ch, refunded)
+		if err != nil {
+			return err
+		}
+
+		err = applyStockMovements(ctx, tx, ch.StockMovements)
+		if err != nil {
+			return err
+		}
+
+		q := `
+			UPDATE checkout_histories
+			SET voided_at = current_timestamp, voided_by = $1, void_shift_id = $2
+			WHERE id = $3;
+		`
+		_, err = tx.ExecContext(ctx, q, voidedBy, ch.VoidShiftID, ch.ID)
+		if err != nil {
+			return err
+		}
+		err = applyGiftCardTransactions(ctx, tx, ch.GiftCardTransactions)
+		if err != nil {
+			return err
+		}
+		return applyLoyaltyEntries(ctx, tx, ch.LoyaltyEntries)
+	})
+}
+
+func lockCheckoutHistory(ctx context.Context, tx *sql.Tx, id string) (*CheckoutHistory, error) {
+	q := `
+		SELECT ` + checkoutHistoryColumns + `
+		FROM checkout_histories
+		WHERE id = $1
+		FOR UPDATE;
+	`
+	ch, err := scanCheckoutHistory(tx.QueryRowContext(ctx, q, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCheckoutNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
+}
+
+// refundedQuantities returns the quantity already refunded per product for
+// the given checkout.
+func refundedQuantities(ctx context.Context, tx *sql.Tx, checkoutHistoryID string) (map[string]int, error) {
+	q := `
+		SELECT product_details
+		FROM checkout_refunds
+		WHERE checkout_history_id = $1;
+	`
+	rows, err := tx.QueryContext(ctx, q, checkoutHistoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	refunded := make(map[string]int)
+	for rows.Next() {
+		var refundDetails RefundDetails
+		err := rows.Scan(&refundDetails)
+		if err != nil {
+			return nil, err
+		}
+		for _, refundDetail := range refundDetails {
+			refunded[refundDetail.ProductID] += refundDetail.Quantity
+		}
+	}
+	return refunded, rows.Err()
+}
+
+// refundedAmounts returns the amount already paid out per payment method by
+// refunds of the given checkout. Refunds to store credit are left out.
+func refundedAmounts(ctx context.Context, tx *sql.Tx, checkoutHistoryID string) (map[PaymentMethod]int64, error) {
+	q := `
+		SELECT method, SUM(amount)
+		FROM checkout_refunds
+		WHERE checkout_history_id = $1 AND method IS NOT NULL
+		GROUP BY method;
+	`
+	rows, err := tx.QueryContext(ctx, q, checkoutHistoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	paidOut := make(map[PaymentMethod]int64)
+	for rows.Next() {
+		var method PaymentMethod
+		var amount int64
+		err := rows.Scan(&method, &amount)
+		if err != nil {
+			return nil, err
+		}
+		paidOut[method] = amount
+	}
+	return paidOut, rows.Err()
+}
+
+// CreateParkedSale implements Repository.
+// Expired parked sales are cleaned up on the way.
+func (d *dbRepository) CreateParkedSale(ctx context.Context, ps *ParkedSale, ttl time.Duration) error {
+	return d.db.StartTx(ctx, func(tx *sql.Tx) error {
+		q := `
+			DELETE FROM parked_sales
+			WHERE expires_at <= current_timestamp;
+		`
+		_, err := tx.ExecContext(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		q = `
+			INSERT INTO parked_sales (
+				id, staff_id, register, customer_id, product_details, expires_at
+			) VALUES (
+				$1, $2, $3, $4, $5, current_timestamp + $6 * interval '1 second'
+			) RETURNING created_at, expires_at;
+		`
+		return tx.QueryRowContext(ctx, q, ps.ID, ps.StaffID, ps.Register, ps.CustomerID, ps.ProductDetails, int64(ttl.Seconds())).
+			Scan(&ps.CreatedAt, &ps.ExpiresAt)
+	})
+}
+
+// GetParkedSale implements Repository.
+func (d *dbRepository) GetParkedSale(ctx context.Context, id string) (*ParkedSale, error) {
+	q := `
+		SELECT id, staff_id, register, customer_id, product_details, created_at, expires_at
+		FROM parked_sales
+		WHERE id = $1 AND expires_at > current_timestamp;
+	`
+	ps := &ParkedSale{}
+	err := d.db.DB().QueryRowContext(ctx, q, id).Scan(&ps.ID, &ps.StaffID, &ps.Register, &ps.CustomerID, &ps.ProductDetails, &ps.CreatedAt, &ps.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrParkedSaleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+// ListParkedSales implements Repository.
+func (d *dbRepository) ListParkedSales(ctx context.Context, req ListParkedSalesPayload) ([]*ParkedSale, error) {
+	q := `
+		SELECT id, staff_id, register, customer_id, product_details, created_at, expires_at
+		FROM parked_sales
+		WHERE expires_at > current_timestamp
+	`
+	paramNo := 1
+	params := make([]interface{}, 0)
+	if req.StaffID != "" {
+		q += fmt.Sprintf("AND staff_id = $%d ", paramNo)
+		paramNo += 1
+		params = append(params, req.StaffID)
+	}
+	if req.Register != "" {
+		q += fmt.Sprintf("AND register = $%d ", paramNo)
+		paramNo += 1
+		params = append(params, req.Register)
+	}
+	q += fmt.Sprintf("ORDER BY created_at DESC OFFSET $%d LIMIT $%d;", paramNo, paramNo+1)
+	params = append(params, req.Offset, req.Limit)
+
+	rows, err := d.db.DB().QueryContext(ctx, q, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*ParkedSale, 0)
+	for rows.Next() {
+		ps := &ParkedSale{}
+		err := rows.Scan(&ps.ID, &ps.StaffID, &ps.Register, &ps.CustomerID, &ps.ProductDetails, &ps.CreatedAt, &ps.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, ps)
+	}
+	return res, rows.Err()
+}
+
+// DeleteParkedSale implements Repository.
+func (d *dbRepository) DeleteParkedSale(ctx context.Context, id string) error {
+	return d.db.StartTx(ctx, func(tx *sql.Tx) error {
+		return deleteParkedSale(ctx, tx, id)
+	})
+}
+
+func deleteParkedSale(ctx context.Context, tx *sql.Tx, id string) error {
+	q := `
+		DELETE FROM parked_sales
+		WHERE id = $1 AND expires_at > current_timestamp;
+	`
+	row, err := tx.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := row.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrParkedSaleNotFound
+	}
+	return nil
+}
+
+func deleteCart(ctx context.Context, tx *sql.Tx, id string) error {
+	q := `
+		DELETE FROM carts
+		WHERE id = $1;
+	`
+	row, err := tx.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := row.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrCartNotFound
+	}
+	return nil
 }
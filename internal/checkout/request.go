@@ -2,21 +2,46 @@ package checkout
 
 import validation "github.com/go-ozzo/ozzo-validation/v4"
 
+// CheckoutRequest is paid either with Payments or, for a single cash
+// payment, with Paid. When ParkedSaleID is set, the customer and product
+// details of the parked sale are used unless given in the request. Stock is
+// taken from LocationID if given, or else from the location of the staff
+// member's open shift.
 type CheckoutRequest struct {
+	StaffID        string                 `json:"-"`
+	LocationID     string                 `json:"locationId"`
+	ParkedSaleID   string                 `json:"parkedSaleId"`
+	CartID         string                 `json:"-"`
 	CustomerID     string                 `json:"customerId"`
 	ProductDetails []ProductDetailRequest `json:"productDetails"`
 	Paid           int                    `json:"paid"`
+	Payments       []PaymentRequest       `json:"payments"`
 	Change         *int                   `json:"change"`
 }
 
 func (p CheckoutRequest) Validate() error {
 	return validation.ValidateStruct(&p,
-		validation.Field(&p.CustomerID, validation.Required),
-		validation.Field(&p.Paid, validation.Required, validation.Min(1)),
+		validation.Field(&p.CustomerID, validation.When(p.ParkedSaleID == "", validation.Required)),
+		validation.Field(&p.Paid, validation.When(len(p.Payments) == 0, validation.Required, validation.Min(1)).Else(validation.Empty)),
+		validation.Field(&p.Payments),
 		validation.Field(&p.Change, validation.NotNil),
 	)
 }
 
+type PaymentRequest struct {
+	Method    PaymentMethod `json:"method"`
+	Amount    int           `json:"amount"`
+	Reference string        `json:"reference"`
+}
+
+func (p PaymentRequest) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Method, validation.Required, validation.In(PaymentMethods...)),
+		validation.Field(&p.Amount, validation.Required, validation.Min(1)),
+		validation.Field(&p.Reference, validation.When(p.Method == PaymentGiftCard, validation.Required), validation.Length(0, 100)),
+	)
+}
+
 type ProductDetailRequest struct {
 	ProductID     string `json:"productId"`
 	Quantity      int    `json:"quantity"`
@@ -30,15 +55,122 @@ func (p ProductDetailRequest) Validate() error {
 	)
 }
 
+type ParkSaleRequest struct {
+	StaffID        string                 `json:"-"`
+	Register       string                 `json:"register"`
+	CustomerID     string                 `json:"customerId"`
+	ProductDetails []ProductDetailRequest `json:"productDetails"`
+}
+
+func (p ParkSaleRequest) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.StaffID, validation.Required),
+		validation.Field(&p.Register, validation.Length(0, 50)),
+		validation.Field(&p.CustomerID, validation.Required),
+		validation.Field(&p.ProductDetails, validation.Required),
+	)
+}
+
+type ListParkedSalesPayload struct {
+	StaffID  string `schema:"staffId" binding:"omitempty"`
+	Register string `schema:"register" binding:"omitempty"`
+	Limit    int    `schema:"limit" binding:"omitempty"`
+	Offset   int    `schema:"offset" binding:"omitempty"`
+}
+
+func (p ListParkedSalesPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Limit, validation.Min(0)),
+		validation.Field(&p.Offset, validation.Min(0)),
+	)
+}
+
+// QuoteRequest checks stock at the same location as CheckoutRequest would.
+type QuoteRequest struct {
+	StaffID        string                 `json:"-"`
+	LocationID     string                 `json:"locationId"`
+	ProductDetails []ProductDetailRequest `json:"productDetails"`
+}
+
+func (p QuoteRequest) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ProductDetails, validation.Required),
+	)
+}
+
+type RefundRequest struct {
+	TransactionID  string                `json:"-"`
+	StaffID        string                `json:"-"`
+	ProductDetails []RefundDetailRequest `json:"productDetails"`
+	// StoreCredit refunds the amount to the customer's store credit instead
+	// of paying it out.
+	StoreCredit bool `json:"storeCredit"`
+	// Method is how the amount is paid out. It has to be a method the
+	// transaction was paid with, and defaults to the first of those that
+	// can be paid out.
+	Method PaymentMethod `json:"method"`
+}
+
+func (p RefundRequest) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.TransactionID, validation.Required),
+		validation.Field(&p.ProductDetails, validation.Required),
+		validation.Field(&p.Method, validation.When(p.StoreCredit, validation.Empty).Else(validation.In(RefundMethods...))),
+	)
+}
+
+type RefundDetailRequest struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+	Damaged   bool   `json:"damaged"`
+}
+
+func (p RefundDetailRequest) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ProductID, validation.Required),
+		validation.Field(&p.Quantity, validation.Required, validation.Min(1)),
+	)
+}
+
+type VoidRequest struct {
+	TransactionID string
+	StaffID       string
+}
+
+func (p VoidRequest) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.TransactionID, validation.Required),
+		validation.Field(&p.StaffID, validation.Required),
+	)
+}
+
 type ListCheckoutHistoriesPayload struct {
 	CustomerID string `schema:"customerId" binding:"omitempty"`
+	StaffID    string `schema:"staffId" binding:"omitempty"`
+	ProductID  string `schema:"productId" binding:"omitempty"`
+	StartDate  string `schema:"startDate" binding:"omitempty"`
+	EndDate    string `schema:"endDate" binding:"omitempty"`
+	PaidMin    *int   `schema:"paidMin" binding:"omitempty"`
+	PaidMax    *int   `schema:"paidMax" binding:"omitempty"`
 	Limit      int    `schema:"limit" binding:"omitempty"`
 	Offset     int    `schema:"offset" binding:"omitempty"`
 	CreatedAt  string `schema:"createdAt" binding:"omitempty"`
+	IsVoided   string `schema:"isVoided" binding:"omitempty"`
 
 	CreatedAtSearchType CreatedAtSearchType
 }
 
+func (p ListCheckoutHistoriesPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.StartDate, validation.Date("2006-01-02")),
+		validation.Field(&p.EndDate, validation.Date("2006-01-02")),
+		validation.Field(&p.PaidMin, validation.Min(0)),
+		validation.Field(&p.PaidMax, validation.Min(0)),
+		validation.Field(&p.Limit, validation.Min(0)),
+		validation.Field(&p.Offset, validation.Min(0)),
+	)
+}
+
 type CreatedAtSearchType int
 
 const (
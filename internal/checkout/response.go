@@ -1,17 +1,104 @@
 package checkout
 
-import "time"
+import (
+	"time"
 
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+// CheckoutHistoryResponse is a checkout. ChangeBreakdown is only set on the
+// response to the checkout itself.
 type CheckoutHistoryResponse struct {
-	TransactionID  string                  `json:"transactionId"`
+	TransactionID   string                  `json:"transactionId"`
+	CustomerID      string                  `json:"customerId"`
+	StaffID         string                  `json:"staffId"`
+	LocationID      string                  `json:"locationId"`
+	ProductDetails  []ProductDetailResponse `json:"productDetails"`
+	Paid            int                     `json:"paid"`
+	Change          int                     `json:"change"`
+	Rounding        int                     `json:"rounding"`
+	ChangeBreakdown []DenominationResponse  `json:"changeBreakdown,omitempty"`
+	Tax             int64                   `json:"tax"`
+	TaxInclusive    bool                    `json:"taxInclusive"`
+	Payments        []PaymentResponse       `json:"payments"`
+	Refunds         []RefundResponse        `json:"refunds"`
+	PointsEarned    int                     `json:"pointsEarned"`
+	VoidedAt        *time.Time              `json:"voidedAt"`
+	VoidedBy        *string                 `json:"voidedBy"`
+	CreatedAt       time.Time               `json:"createdAt"`
+}
+
+type ProductDetailResponse struct {
+	ProductID   string                  `json:"productId"`
+	Quantity    int                     `json:"quantity"`
+	Name        string                  `json:"name"`
+	SKU         string                  `json:"sku"`
+	Category    product.ProductCategory `json:"category"`
+	Price       int64                   `json:"price"`
+	Discount    int64                   `json:"discount"`
+	PromotionID string                  `json:"promotionId,omitempty"`
+	TaxRate     int                     `json:"taxRate"`
+	Tax         int64                   `json:"tax"`
+	Total       int64                   `json:"total"`
+	Points      int                     `json:"points"`
+}
+
+type ParkedSaleResponse struct {
+	ParkedSaleID   string                  `json:"parkedSaleId"`
+	StaffID        string                  `json:"staffId"`
+	Register       string                  `json:"register"`
 	CustomerID     string                  `json:"customerId"`
 	ProductDetails []ProductDetailResponse `json:"productDetails"`
-	Paid           int                     `json:"paid"`
-	Change         int                     `json:"change"`
 	CreatedAt      time.Time               `json:"createdAt"`
+	ExpiresAt      time.Time               `json:"expiresAt"`
 }
 
-type ProductDetailResponse struct {
+type PaymentResponse struct {
+	Method    PaymentMethod `json:"method"`
+	Amount    int           `json:"amount"`
+	Reference string        `json:"reference"`
+}
+
+// QuoteResponse prices a basket. CashAmountDue is AmountDue rounded for a
+// sale paid fully in cash.
+type QuoteResponse struct {
+	ProductDetails []QuoteLineResponse `json:"productDetails"`
+	Subtotal       int64               `json:"subtotal"`
+	Discount       int64               `json:"discount"`
+	Tax            int64               `json:"tax"`
+	TaxInclusive   bool                `json:"taxInclusive"`
+	AmountDue      int64               `json:"amountDue"`
+	CashAmountDue  int64               `json:"cashAmountDue"`
+	PointsEarned   int                 `json:"pointsEarned"`
+	IsCheckoutable bool                `json:"isCheckoutable"`
+}
+
+type QuoteLineResponse struct {
+	ProductDetailResponse
+	Problem string `json:"problem,omitempty"`
+}
+
+type RefundResponse struct {
+	RefundID       string                 `json:"refundId"`
+	TransactionID  string                 `json:"transactionId"`
+	ProductDetails []RefundDetailResponse `json:"productDetails"`
+	Amount         int64                  `json:"amount"`
+	StoreCredit    bool                   `json:"storeCredit"`
+	Method         PaymentMethod          `json:"method,omitempty"`
+	CreatedAt      time.Time              `json:"createdAt"`
+}
+
+type RefundDetailResponse struct {
 	ProductID string `json:"productId"`
 	Quantity  int    `json:"quantity"`
+	Price     int64  `json:"price"`
+	Tax       int64  `json:"tax"`
+	Total     int64  `json:"total"`
+	Points    int    `json:"points"`
+	Damaged   bool   `json:"damaged"`
+}
+
+type DenominationResponse struct {
+	Denomination int `json:"denomination"`
+	Count        int `json:"count"`
 }
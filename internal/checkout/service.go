@@ -2,92 +2,327 @@ package checkout
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/citadel-corp/eniqilo-store/internal/common/id"
+	"github.com/citadel-corp/eniqilo-store/internal/common/response"
+	"github.com/citadel-corp/eniqilo-store/internal/giftcard"
+	"github.com/citadel-corp/eniqilo-store/internal/location"
+	"github.com/citadel-corp/eniqilo-store/internal/loyalty"
 	"github.com/citadel-corp/eniqilo-store/internal/product"
+	"github.com/citadel-corp/eniqilo-store/internal/promotion"
+	"github.com/citadel-corp/eniqilo-store/internal/shift"
+	"github.com/citadel-corp/eniqilo-store/internal/tax"
 	"github.com/citadel-corp/eniqilo-store/internal/user"
+	"github.com/rs/zerolog/log"
 )
 
 type Service interface {
-	CheckoutProducts(ctx context.Context, req CheckoutRequest) error
-	ListCheckoutHistories(ctx context.Context, req ListCheckoutHistoriesPayload) ([]*CheckoutHistoryResponse, error)
+	CheckoutProducts(ctx context.Context, req CheckoutRequest) (*CheckoutHistoryResponse, error)
+	QuoteCheckout(ctx context.Context, req QuoteRequest) (*QuoteResponse, error)
+	ListCheckoutHistories(ctx context.Context, req ListCheckoutHistoriesPayload) ([]*CheckoutHistoryResponse, *response.Pagination, error)
+	GetReceipt(ctx context.Context, transactionID string) (*Receipt, error)
+	RefundCheckout(ctx context.Context, req RefundRequest) (*RefundResponse, error)
+	VoidCheckout(ctx context.Context, req VoidRequest) error
+	ParkSale(ctx context.Context, req ParkSaleRequest) (*ParkedSaleResponse, error)
+	GetParkedSale(ctx context.Context, id string) (*ParkedSaleResponse, error)
+	ListParkedSales(ctx context.Context, req ListParkedSalesPayload) ([]*ParkedSaleResponse, error)
+	DeleteParkedSale(ctx context.Context, id string) error
 }
 
+var (
+	voidWindowStr    = os.Getenv("CHECKOUT_VOID_WINDOW")
+	parkedSaleTTLStr = os.Getenv("PARKED_SALE_TTL")
+)
+
 type checkoutService struct {
-	repository        Repository
-	userRepository    user.Repository
-	productRepository product.Repository
+	repository          Repository
+	userRepository      user.Repository
+	productRepository   product.Repository
+	promotionRepository promotion.Repository
+	taxRepository       tax.Repository
+	shiftRepository     shift.Repository
+	loyaltyRepository   loyalty.Repository
+	giftCardRepository  giftcard.Repository
+	locationRepository  location.Repository
+	lowStockNotifier    product.Notifier
 }
 
-func NewService(repository Repository, userRepository user.Repository, productRepository product.Repository) Service {
+func NewService(repository Repository, userRepository user.Repository, productRepository product.Repository,
+	promotionRepository promotion.Repository, taxRepository tax.Repository, shiftRepository shift.Repository,
+	loyaltyRepository loyalty.Repository, giftCardRepository giftcard.Repository, locationRepository location.Repository,
+	lowStockNotifier product.Notifier) Service {
 	return &checkoutService{
-		repository:        repository,
-		userRepository:    userRepository,
-		productRepository: productRepository,
+		repository:          repository,
+		userRepository:      userRepository,
+		productRepository:   productRepository,
+		promotionRepository: promotionRepository,
+		taxRepository:       taxRepository,
+		shiftRepository:     shiftRepository,
+		loyaltyRepository:   loyaltyRepository,
+		giftCardRepository:  giftCardRepository,
+		locationRepository:  locationRepository,
+		lowStockNotifier:    lowStockNotifier,
 	}
 }
 
 // CheckoutProducts implements Service.
-func (s *checkoutService) CheckoutProducts(ctx context.Context, req CheckoutRequest) error {
+func (s *checkoutService) CheckoutProducts(ctx context.Context, req CheckoutRequest) (*CheckoutHistoryResponse, error) {
 	if err := req.Validate(); err != nil {
-		return fmt.Errorf("%w: %w", ErrValidationFailed, err)
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
 	}
-	productIDs := make([]string, len(req.ProductDetails))
-	productMap := make(map[string]ProductDetailRequest, len(req.ProductDetails))
-	productDetails := make([]ProductDetail, len(req.ProductDetails))
-	for i, productDetail := range req.ProductDetails {
-		if err := productDetail.Validate(); err != nil {
-			return fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	productDetails, err := toProductDetails(req.ProductDetails)
+	if err != nil {
+		return nil, err
+	}
+	if req.ParkedSaleID != "" {
+		parkedSale, err := s.repository.GetParkedSale(ctx, req.ParkedSaleID)
+		if err != nil {
+			return nil, err
 		}
-		productIDs[i] = productDetail.ProductID
-		productMap[productDetail.ProductID] = productDetail
-		productDetails[i] = ProductDetail{
-			ProductID: productDetail.ProductID,
-			Quantity:  productDetail.Quantity,
+		if req.CustomerID == "" {
+			req.CustomerID = parkedSale.CustomerID
+		}
+		if len(productDetails) == 0 {
+			productDetails = parkedSale.ProductDetails
 		}
 	}
 
-	user, err := s.userRepository.GetByID(ctx, req.CustomerID)
+	customer, err := s.userRepository.GetByID(ctx, req.CustomerID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil, ErrCustomerNotFound
+	}
 	if err != nil {
-		return err
+		return nil, err
+	}
+	rules, err := s.pricingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// cash taken on the sale is attributed to the staff member's open shift
+	var shiftID *string
+	openShift, err := s.shiftRepository.GetOpenByStaffID(ctx, req.StaffID)
+	if err != nil && !errors.Is(err, shift.ErrShiftNotFound) {
+		return nil, err
+	}
+	if openShift != nil {
+		shiftID = &openShift.ID
+	}
+	locationID, err := s.saleLocation(ctx, req.LocationID, openShift)
+	if err != nil {
+		return nil, err
+	}
+	ch := &CheckoutHistory{
+		ID:             id.GenerateStringID(16),
+		UserID:         customer.ID,
+		StaffID:        req.StaffID,
+		ShiftID:        shiftID,
+		LocationID:     locationID,
+		ProductDetails: productDetails,
+		Change:         *req.Change,
+		Payments:       toPayments(req),
+		ParkedSaleID:   req.ParkedSaleID,
+		CartID:         req.CartID,
+	}
+	redeemed, err := redeemedPoints(ch.Payments)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	ch.GiftCardTransactions, err = s.giftCardRedemptions(ctx, ch)
+	if err != nil {
+		return nil, err
+	}
+	err = s.repository.CreateCheckoutHistory(ctx, ch, func(products []*product.Product) error {
+		quote := buildQuote(ch.ProductDetails, products, ch.LocationID, rules)
+		if err := quote.Err(); err != nil {
+			return err
+		}
+		ch.ProductDetails = quote.ProductDetails()
+		ch.Tax = quote.Tax
+		ch.TaxInclusive = quote.TaxInclusive
+
+		paid, rounding, err := tender(quote.Total, ch.Payments, ch.Change)
+		if err != nil {
+			return err
+		}
+		ch.Paid = paid
+		ch.Rounding = rounding
+		scaleEarnedPoints(ch.ProductDetails, ch.Payments, quote.Total)
+		ch.LoyaltyEntries = checkoutLoyaltyEntries(ch, redeemed)
+		ch.StockMovements = saleStockMovements(ch)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notifyLowStock(ctx, ch.LowStockAlerts)
+
+	payments := make([]PaymentResponse, len(ch.Payments))
+	for i := range ch.Payments {
+		payments[i] = toPaymentResponse(&ch.Payments[i])
+	}
+	res := toCheckoutHistoryResponse(ch, payments, make([]RefundResponse, 0))
+	res.ChangeBreakdown = changeBreakdown(ch.Change)
+	return res, nil
+}
+
+// QuoteCheckout implements Service.
+func (s *checkoutService) QuoteCheckout(ctx context.Context, req QuoteRequest) (*QuoteResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	productDetails, err := toProductDetails(req.ProductDetails)
+	if err != nil {
+		return nil, err
+	}
+	productIDs := make([]string, len(productDetails))
+	for i, productDetail := range productDetails {
+		productIDs[i] = productDetail.ProductID
 	}
 	products, err := s.productRepository.GetByMultipleID(ctx, productIDs)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if len(productIDs) != len(products) {
-		return ErrProductNotFound
+	rules, err := s.pricingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var openShift *shift.Shift
+	if req.StaffID != "" {
+		openShift, err = s.shiftRepository.GetOpenByStaffID(ctx, req.StaffID)
+		if err != nil && !errors.Is(err, shift.ErrShiftNotFound) {
+			return nil, err
+		}
+	}
+	locationID, err := s.saleLocation(ctx, req.LocationID, openShift)
+	if err != nil {
+		return nil, err
 	}
 
-	price := int64(0)
-	for _, product := range products {
-		if !product.IsAvailable {
-			return ErrProductUnavailable
+	quote := buildQuote(productDetails, products, locationID, rules)
+	lines := make([]QuoteLineResponse, len(quote.Lines))
+	for i, line := range quote.Lines {
+		lines[i] = QuoteLineResponse{
+			ProductDetailResponse: toProductDetailResponse(line.ProductDetail),
 		}
-		if product.Stock-productMap[product.ID].Quantity < 0 {
-			return ErrProductStockNotEnough
+		if line.Problem != nil {
+			lines[i].Problem = line.Problem.Error()
 		}
-		price += product.Price * int64(productMap[product.ID].Quantity)
 	}
-	if int64(req.Paid) < price {
-		return ErrNotEnoughMoney
+	return &QuoteResponse{
+		ProductDetails: lines,
+		Subtotal:       quote.Subtotal,
+		Discount:       quote.Discount,
+		Tax:            quote.Tax,
+		TaxInclusive:   quote.TaxInclusive,
+		AmountDue:      quote.Total,
+		CashAmountDue:  roundCash(quote.Total),
+		PointsEarned:   quote.Points,
+		IsCheckoutable: quote.Err() == nil,
+	}, nil
+}
+
+// saleLocation returns the location a sale takes stock from: locationID if
+// given, or else the location of the open shift, or else the default location.
+func (s *checkoutService) saleLocation(ctx context.Context, locationID string, openShift *shift.Shift) (string, error) {
+	if locationID == "" {
+		if openShift != nil {
+			return openShift.LocationID, nil
+		}
+		return location.DefaultID, nil
 	}
-	change := req.Paid - int(price)
-	if change != *req.Change {
-		return ErrWrongChange
+	_, err := s.locationRepository.GetByID(ctx, locationID)
+	if errors.Is(err, location.ErrLocationNotFound) {
+		return "", ErrLocationNotFound
 	}
-	ch := &CheckoutHistory{
-		ID:             id.GenerateStringID(16),
-		UserID:         user.ID,
-		ProductDetails: productDetails,
-		Paid:           req.Paid,
-		Change:         *req.Change,
+	if err != nil {
+		return "", err
 	}
-	return s.repository.CreateCheckoutHistory(ctx, ch)
+	return locationID, nil
 }
 
-func (s *checkoutService) ListCheckoutHistories(ctx context.Context, req ListCheckoutHistoriesPayload) ([]*CheckoutHistoryResponse, error) {
+func (s *checkoutService) pricingRules(ctx context.Context) (*pricingRules, error) {
+	promotions, err := s.promotionRepository.ListActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+	taxRates, err := s.taxRepository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	multipliers, err := s.loyaltyRepository.ListMultipliers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pricingRules{
+		promotions:   promotions,
+		taxRates:     tax.NewRates(taxRates),
+		taxInclusive: tax.Inclusive(),
+		loyalty:      loyalty.NewRules(multipliers),
+	}, nil
+}
+
+func toProductDetails(reqs []ProductDetailRequest) (ProductDetails, error) {
+	productDetails := make(ProductDetails, len(reqs))
+	for i, productDetail := range reqs {
+		if err := productDetail.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+		}
+		productDetails[i] = ProductDetail{
+			ProductID: productDetail.ProductID,
+			Quantity:  productDetail.Quantity,
+		}
+	}
+	return productDetails, nil
+}
+
+// toPayments returns the payments of req. A request without payments was paid
+// in cash with Paid.
+func toPayments(req CheckoutRequest) []Payment {
+	if len(req.Payments) == 0 {
+		return []Payment{{
+			ID:     id.GenerateStringID(16),
+			Method: PaymentCash,
+			Amount: req.Paid,
+		}}
+	}
+	payments := make([]Payment, len(req.Payments))
+	for i, payment := range req.Payments {
+		payments[i] = Payment{
+			ID:        id.GenerateStringID(16),
+			Method:    payment.Method,
+			Amount:    payment.Amount,
+			Reference: payment.Reference,
+		}
+	}
+	return payments
+}
+
+func toProductDetailResponse(productDetail ProductDetail) ProductDetailResponse {
+	return ProductDetailResponse{
+		ProductID:   productDetail.ProductID,
+		Quantity:    productDetail.Quantity,
+		Name:        productDetail.Name,
+		SKU:         productDetail.SKU,
+		Category:    productDetail.Category,
+		Price:       productDetail.Price,
+		Discount:    productDetail.Discount,
+		PromotionID: productDetail.PromotionID,
+		TaxRate:     productDetail.TaxRate,
+		Tax:         productDetail.Tax,
+		Total:       productDetail.Total,
+		Points:      productDetail.Points,
+	}
+}
+
+// ListCheckoutHistories implements Service.
+func (s *checkoutService) ListCheckoutHistories(ctx context.Context, req ListCheckoutHistoriesPayload) ([]*CheckoutHistoryResponse, *response.Pagination, error) {
+	if err := req.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
 	req.CreatedAtSearchType = Descending
 	switch req.CreatedAt {
 	case "asc":
@@ -100,25 +335,376 @@ func (s *checkoutService) ListCheckoutHistories(ctx context.Context, req ListChe
 	}
 	checkoutHistories, err := s.repository.ListCheckoutHistories(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	total, err := s.repository.CountCheckoutHistories(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	checkoutHistoryIDs := make([]string, len(checkoutHistories))
+	for i, checkoutHistory := range checkoutHistories {
+		checkoutHistoryIDs[i] = checkoutHistory.ID
+	}
+	refunds, err := s.repository.ListRefunds(ctx, checkoutHistoryIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	refundsByCheckoutID := make(map[string][]RefundResponse, len(checkoutHistories))
+	for _, refund := range refunds {
+		refundsByCheckoutID[refund.CheckoutHistoryID] = append(refundsByCheckoutID[refund.CheckoutHistoryID], *toRefundResponse(refund))
+	}
+	payments, err := s.repository.ListPayments(ctx, checkoutHistoryIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	paymentsByCheckoutID := make(map[string][]PaymentResponse, len(checkoutHistories))
+	for _, payment := range payments {
+		paymentsByCheckoutID[payment.CheckoutHistoryID] = append(paymentsByCheckoutID[payment.CheckoutHistoryID], toPaymentResponse(payment))
 	}
 	res := make([]*CheckoutHistoryResponse, len(checkoutHistories))
 	for i, checkoutHistory := range checkoutHistories {
-		productDetails := make([]ProductDetailResponse, len(checkoutHistory.ProductDetails))
-		for j, productDetail := range checkoutHistory.ProductDetails {
-			productDetails[j] = ProductDetailResponse{
-				ProductID: productDetail.ProductID,
-				Quantity:  productDetail.Quantity,
+		res[i] = toCheckoutHistoryResponse(checkoutHistory, paymentsByCheckoutID[checkoutHistory.ID], refundsByCheckoutID[checkoutHistory.ID])
+	}
+	return res, &response.Pagination{
+		Limit:  req.Limit,
+		Offset: req.Offset,
+		Total:  total,
+	}, nil
+}
+
+// GetReceipt implements Service.
+func (s *checkoutService) GetReceipt(ctx context.Context, transactionID string) (*Receipt, error) {
+	ch, err := s.repository.GetCheckoutHistory(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+	payments, err := s.repository.ListPayments(ctx, []string{ch.ID})
+	if err != nil {
+		return nil, err
+	}
+	receipt := newReceipt(ch, make([]Payment, len(payments)))
+	for i, payment := range payments {
+		receipt.Payments[i] = *payment
+	}
+
+	customer, err := s.userRepository.GetByID(ctx, ch.UserID)
+	if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+		return nil, err
+	}
+	if customer != nil {
+		receipt.CustomerName = customer.Name
+	}
+	if ch.StaffID != "" {
+		staff, err := s.userRepository.GetByID(ctx, ch.StaffID)
+		if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+			return nil, err
+		}
+		if staff != nil {
+			receipt.StaffName = staff.Name
+		}
+	}
+	return receipt, nil
+}
+
+func toCheckoutHistoryResponse(ch *CheckoutHistory, payments []PaymentResponse, refunds []RefundResponse) *CheckoutHistoryResponse {
+	productDetails := make([]ProductDetailResponse, len(ch.ProductDetails))
+	for i, productDetail := range ch.ProductDetails {
+		productDetails[i] = toProductDetailResponse(productDetail)
+	}
+	res := &CheckoutHistoryResponse{
+		TransactionID:  ch.ID,
+		CustomerID:     ch.UserID,
+		StaffID:        ch.StaffID,
+		LocationID:     ch.LocationID,
+		ProductDetails: productDetails,
+		Paid:           ch.Paid,
+		Change:         ch.Change,
+		Rounding:       ch.Rounding,
+		Tax:            ch.Tax,
+		TaxInclusive:   ch.TaxInclusive,
+		Payments:       payments,
+		Refunds:        refunds,
+		PointsEarned:   earnedPoints(ch.ProductDetails),
+		VoidedAt:       ch.VoidedAt,
+		VoidedBy:       ch.VoidedBy,
+		CreatedAt:      ch.CreatedAt,
+	}
+	if res.Payments == nil {
+		res.Payments = make([]PaymentResponse, 0)
+	}
+	if res.Refunds == nil {
+		res.Refunds = make([]RefundResponse, 0)
+	}
+	return res
+}
+
+func toPaymentResponse(payment *Payment) PaymentResponse {
+	reference := payment.Reference
+	if payment.Method == PaymentGiftCard {
+		reference = maskGiftCardCode(reference)
+	}
+	return PaymentResponse{
+		Method:    payment.Method,
+		Amount:    payment.Amount,
+		Reference: reference,
+	}
+}
+
+// RefundCheckout implements Service.
+func (s *checkoutService) RefundCheckout(ctx context.Context, req RefundRequest) (*RefundResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	refundDetails := make(RefundDetails, len(req.ProductDetails))
+	for i, refundDetail := range req.ProductDetails {
+		if err := refundDetail.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+		}
+		refundDetails[i] = RefundDetail{
+			ProductID: refundDetail.ProductID,
+			Quantity:  refundDetail.Quantity,
+			Damaged:   refundDetail.Damaged,
+		}
+	}
+
+	// cash handed back on the refund comes out of the staff member's open shift
+	shiftID, err := s.openShiftID(ctx, req.StaffID)
+	if err != nil {
+		return nil, err
+	}
+	refund := &Refund{
+		ID:                id.GenerateStringID(16),
+		CheckoutHistoryID: req.TransactionID,
+		ShiftID:           shiftID,
+		ProductDetails:    refundDetails,
+		StoreCredit:       req.StoreCredit,
+	}
+	payments, err := s.repository.ListPayments(ctx, []string{req.TransactionID})
+	if err != nil {
+		return nil, err
+	}
+	err = s.repository.CreateRefund(ctx, refund, func(ch *CheckoutHistory, refunded map[string]int, paidOut map[PaymentMethod]int64) error {
+		if ch.VoidedAt != nil {
+			return ErrCheckoutVoided
+		}
+		if err := prorateRefund(ch, refund, refunded); err != nil {
+			return err
+		}
+		if !refund.StoreCredit {
+			method, err := refundMethod(payments, ch.Change, paidOut, req.Method, refund.Amount)
+			if err != nil {
+				return err
 			}
+			refund.Method = method
 		}
-		res[i] = &CheckoutHistoryResponse{
-			TransactionID:  checkoutHistory.ID,
-			CustomerID:     checkoutHistory.UserID,
-			ProductDetails: productDetails,
-			Paid:           checkoutHistory.Paid,
-			Change:         checkoutHistory.Change,
-			CreatedAt:      checkoutHistory.CreatedAt,
+		refund.LoyaltyEntries = refundLoyaltyEntries(ch, refund)
+		refund.StockMovements = refundStockMovements(ch, refund, req.StaffID)
+		if refund.StoreCredit {
+			refund.StoreCreditTransaction = &giftcard.Transaction{
+				ID:                id.GenerateStringID(16),
+				GiftCardID:        id.GenerateStringID(16),
+				CheckoutHistoryID: &ch.ID,
+				RefundID:          &refund.ID,
+				Type:              giftcard.TransactionCredit,
+				Amount:            refund.Amount,
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toRefundResponse(refund), nil
+}
+
+// openShiftID returns the ID of the staff member's open shift, or nil if they
+// have none.
+func (s *checkoutService) openShiftID(ctx context.Context, staffID string) (*string, error) {
+	openShift, err := s.shiftRepository.GetOpenByStaffID(ctx, staffID)
+	if errors.Is(err, shift.ErrShiftNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &openShift.ID, nil
+}
+
+// VoidCheckout implements Service.
+func (s *checkoutService) VoidCheckout(ctx context.Context, req VoidRequest) error {
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	staff, err := s.userRepository.GetByID(ctx, req.StaffID)
+	// a staff member who no longer exists cannot be allowed to void
+	if errors.Is(err, user.ErrUserNotFound) {
+		return ErrVoidForbidden
+	}
+	if err != nil {
+		return err
+	}
+	voidWindow, err := time.ParseDuration(voidWindowStr)
+	if err != nil {
+		voidWindow = 15 * time.Minute
+	}
+	payments, err := s.repository.ListPayments(ctx, []string{req.TransactionID})
+	if err != nil {
+		return err
+	}
+	checkoutPayments := make([]Payment, len(payments))
+	for i, payment := range payments {
+		checkoutPayments[i] = *payment
+	}
+	redeemed, err := redeemedPoints(checkoutPayments)
+	if err != nil {
+		return err
+	}
+	giftCardReversals, err := s.giftCardReversals(ctx, req.TransactionID)
+	if err != nil {
+		return err
+	}
+	// like a refund, cash handed back on a void comes out of the open shift
+	// of the staff member voiding, not the shift of the sale
+	shiftID, err := s.openShiftID(ctx, staff.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.VoidCheckoutHistory(ctx, req.TransactionID, staff.ID, func(ch *CheckoutHistory, refunded map[string]int) error {
+		if ch.VoidedAt != nil {
+			return ErrCheckoutVoided
+		}
+		if len(refunded) > 0 {
+			return ErrCheckoutRefunded
+		}
+		if ch.StaffID != staff.ID && !staff.IsManager {
+			return ErrVoidForbidden
+		}
+		if time.Since(ch.CreatedAt) > voidWindow {
+			return ErrVoidWindowExpired
+		}
+		ch.VoidShiftID = shiftID
+		ch.LoyaltyEntries = voidLoyaltyEntries(ch, redeemed)
+		ch.GiftCardTransactions = giftCardReversals
+		ch.StockMovements = voidStockMovements(ch, staff.ID)
+		return nil
+	})
+}
+
+// ParkSale implements Service.
+func (s *checkoutService) ParkSale(ctx context.Context, req ParkSaleRequest) (*ParkedSaleResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	productDetails, err := toProductDetails(req.ProductDetails)
+	if err != nil {
+		return nil, err
+	}
+	customer, err := s.userRepository.GetByID(ctx, req.CustomerID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil, ErrCustomerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := time.ParseDuration(parkedSaleTTLStr)
+	if err != nil {
+		ttl = 2 * time.Hour
+	}
+
+	ps := &ParkedSale{
+		ID:             id.GenerateStringID(16),
+		StaffID:        req.StaffID,
+		Register:       req.Register,
+		CustomerID:     customer.ID,
+		ProductDetails: productDetails,
+	}
+	err = s.repository.CreateParkedSale(ctx, ps, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return toParkedSaleResponse(ps), nil
+}
+
+// GetParkedSale implements Service.
+func (s *checkoutService) GetParkedSale(ctx context.Context, id string) (*ParkedSaleResponse, error) {
+	ps, err := s.repository.GetParkedSale(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toParkedSaleResponse(ps), nil
+}
+
+// ListParkedSales implements Service.
+func (s *checkoutService) ListParkedSales(ctx context.Context, req ListParkedSalesPayload) ([]*ParkedSaleResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+	parkedSales, err := s.repository.ListParkedSales(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*ParkedSaleResponse, len(parkedSales))
+	for i, ps := range parkedSales {
+		res[i] = toParkedSaleResponse(ps)
 	}
 	return res, nil
 }
+
+// DeleteParkedSale implements Service.
+func (s *checkoutService) DeleteParkedSale(ctx context.Context, id string) error {
+	return s.repository.DeleteParkedSale(ctx, id)
+}
+
+func toParkedSaleResponse(ps *ParkedSale) *ParkedSaleResponse {
+	productDetails := make([]ProductDetailResponse, len(ps.ProductDetails))
+	for i, productDetail := range ps.ProductDetails {
+		productDetails[i] = toProductDetailResponse(productDetail)
+	}
+	return &ParkedSaleResponse{
+		ParkedSaleID:   ps.ID,
+		StaffID:        ps.StaffID,
+		Register:       ps.Register,
+		CustomerID:     ps.CustomerID,
+		ProductDetails: productDetails,
+		CreatedAt:      ps.CreatedAt,
+		ExpiresAt:      ps.ExpiresAt,
+	}
+}
+
+func toRefundResponse(refund *Refund) *RefundResponse {
+	productDetails := make([]RefundDetailResponse, len(refund.ProductDetails))
+	for i, refundDetail := range refund.ProductDetails {
+		productDetails[i] = RefundDetailResponse{
+			ProductID: refundDetail.ProductID,
+			Quantity:  refundDetail.Quantity,
+			Price:     refundDetail.Price,
+			Tax:       refundDetail.Tax,
+			Total:     refundDetail.Total,
+			Points:    refundDetail.Points,
+			Damaged:   refundDetail.Damaged,
+		}
+	}
+	return &RefundResponse{
+		RefundID:       refund.ID,
+		TransactionID:  refund.CheckoutHistoryID,
+		ProductDetails: productDetails,
+		Amount:         refund.Amount,
+		StoreCredit:    refund.StoreCredit,
+		Method:         refund.Method,
+		CreatedAt:      refund.CreatedAt,
+	}
+}
+
+// notifyLowStock sends the low stock alerts raised by a checkout. The sale
+// has already gone through, so a failed alert is only logged.
+func (s *checkoutService) notifyLowStock(ctx context.Context, alerts []product.LowStockAlert) {
+	for _, alert := range alerts {
+		if err := s.lowStockNotifier.NotifyLowStock(ctx, alert); err != nil {
+			log.Error().Msg(fmt.Sprintf("Cannot send low stock alert for product %s: %v", alert.ProductID, err))
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package checkout
+
+import (
+	"github.com/citadel-corp/eniqilo-store/internal/common/id"
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+// saleStockMovements takes the sold quantities of ch out of stock.
+func saleStockMovements(ch *CheckoutHistory) []product.StockMovement {
+	movements := make([]product.StockMovement, len(ch.ProductDetails))
+	for i, productDetail := range ch.ProductDetails {
+		movements[i] = newStockMovement(productDetail.ProductID, ch.LocationID, -productDetail.Quantity, product.MovementSale, ch.StaffID, ch.ID)
+	}
+	return movements
+}
+
+// voidStockMovements puts the sold quantities of a voided checkout back into
+// stock.
+func voidStockMovements(ch *CheckoutHistory, voidedBy string) []product.StockMovement {
+	movements := make([]product.StockMovement, len(ch.ProductDetails))
+	for i, productDetail := range ch.ProductDetails {
+		movements[i] = newStockMovement(productDetail.ProductID, ch.LocationID, productDetail.Quantity, product.MovementVoid, voidedBy, ch.ID)
+	}
+	return movements
+}
+
+// refundStockMovements restocks the refunded lines at the location they were
+// sold from. Damaged lines go to damaged stock and are not sellable stock
+// movements.
+func refundStockMovements(ch *CheckoutHistory, refund *Refund, staffID string) []product.StockMovement {
+	movements := make([]product.StockMovement, 0, len(refund.ProductDetails))
+	for _, refundDetail := range refund.ProductDetails {
+		if refundDetail.Damaged {
+			continue
+		}
+		movements = append(movements, newStockMovement(refundDetail.ProductID, ch.LocationID, refundDetail.Quantity, product.MovementRefund, staffID, refund.ID))
+	}
+	return movements
+}
+
+func newStockMovement(productID, locationID string, delta int, reason product.MovementReason, actorID, referenceID string) product.StockMovement {
+	movement := product.StockMovement{
+		ID:          id.GenerateStringID(16),
+		ProductID:   productID,
+		LocationID:  locationID,
+		Delta:       delta,
+		Reason:      reason,
+		ReferenceID: &referenceID,
+	}
+	if actorID != "" {
+		movement.ActorID = &actorID
+	}
+	return movement
+}
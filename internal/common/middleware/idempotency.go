@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/response"
+	"github.com/rs/zerolog/log"
+)
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type Idempotency struct {
+	store IdempotencyStore
+	ttl   time.Duration
+}
+
+func NewIdempotency(store IdempotencyStore, ttl time.Duration) *Idempotency {
+	return &Idempotency{store: store, ttl: ttl}
+}
+
+// Handle makes next idempotent for requests carrying an Idempotency-Key header.
+// The first request with a key is executed and its response stored; retries
+// with the same key and body replay the stored response until the key expires.
+// Reusing a key with a different body is rejected. Requests without the header
+// are passed through untouched.
+func (i *Idempotency) Handle(next func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next(w, r)
+			return
+		}
+		if len(key) > 255 {
+			response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+				Message: "Bad request",
+				Error:   "idempotency key must be at most 255 characters",
+			})
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+				Message: "Bad request",
+				Error:   err.Error(),
+			})
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		// keys are scoped per caller and endpoint so that two staff members
+		// cannot collide on the same key
+		subject, _ := r.Context().Value(ContextAuthKey{}).(string)
+		scope := fmt.Sprintf("%s %s %s", subject, r.Method, r.URL.Path)
+		sum := sha256.Sum256(body)
+		fingerprint := hex.EncodeToString(sum[:])
+
+		rec, reserved, err := i.store.Reserve(r.Context(), scope, key, fingerprint, i.ttl)
+		if err != nil {
+			response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+				Message: "Internal server error",
+				Error:   err.Error(),
+			})
+			return
+		}
+		if !reserved {
+			if rec.Fingerprint != fingerprint {
+				response.JSON(w, http.StatusUnprocessableEntity, response.ResponseBody{
+					Message: "Unprocessable entity",
+					Error:   "idempotency key was already used with a different request body",
+				})
+				return
+			}
+			if rec.StatusCode == 0 {
+				response.JSON(w, http.StatusConflict, response.ResponseBody{
+					Message: "Conflict",
+					Error:   "a request with this idempotency key is still being processed",
+				})
+				return
+			}
+			w.Header().Set("Content-Type", rec.ContentType)
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(rec.StatusCode)
+			w.Write(rec.Body)
+			return
+		}
+
+		// a panic in next is recovered further up, so the key is released
+		// here or it would stay reserved and every retry would conflict
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				if err := i.store.Release(r.Context(), scope, key); err != nil {
+					log.Error().Msg(fmt.Sprintf("Cannot release idempotency key: %v", err))
+				}
+				panic(rvr)
+			}
+		}()
+
+		recWriter := NewLogResponseWriter(w)
+		next(recWriter, r)
+		if recWriter.statusCode == 0 {
+			recWriter.statusCode = http.StatusOK
+		}
+
+		// server errors are not stored so that the client can retry them
+		if recWriter.statusCode >= 500 {
+			if err := i.store.Release(r.Context(), scope, key); err != nil {
+				log.Error().Msg(fmt.Sprintf("Cannot release idempotency key: %v", err))
+			}
+			return
+		}
+		err = i.store.Complete(r.Context(), scope, key, &IdempotencyRecord{
+			StatusCode:  recWriter.statusCode,
+			ContentType: recWriter.Header().Get("Content-Type"),
+			Body:        recWriter.buf.Bytes(),
+		})
+		if err != nil {
+			log.Error().Msg(fmt.Sprintf("Cannot store idempotent response: %v", err))
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/db"
+)
+
+type IdempotencyRecord struct {
+	Fingerprint string
+	// StatusCode is zero while the original request is still in flight.
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+type IdempotencyStore interface {
+	// Reserve claims key within scope for a request with the given fingerprint.
+	// If key is already claimed and has not expired, the existing record is
+	// returned and reserved is false.
+	Reserve(ctx context.Context, scope, key, fingerprint string, ttl time.Duration) (rec *IdempotencyRecord, reserved bool, err error)
+	Complete(ctx context.Context, scope, key string, rec *IdempotencyRecord) error
+	Release(ctx context.Context, scope, key string) error
+}
+
+type dbIdempotencyStore struct {
+	db *db.DB
+}
+
+func NewIdempotencyStore(db *db.DB) IdempotencyStore {
+	return &dbIdempotencyStore{db: db}
+}
+
+// Reserve implements IdempotencyStore.
+func (d *dbIdempotencyStore) Reserve(ctx context.Context, scope, key, fingerprint string, ttl time.Duration) (*IdempotencyRecord, bool, error) {
+	q := `
+		INSERT INTO idempotency_keys (
+			scope, key, fingerprint, expires_at
+		) VALUES (
+			$1, $2, $3, current_timestamp + $4 * interval '1 second'
+		)
+		ON CONFLICT (scope, key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, status_code = NULL, content_type = NULL, response_body = NULL,
+			created_at = current_timestamp, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at < current_timestamp
+		RETURNING key;
+	`
+	var k string
+	err := d.db.DB().QueryRowContext(ctx, q, scope, key, fingerprint, int64(ttl.Seconds())).Scan(&k)
+	if err == nil {
+		return nil, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, err
+	}
+
+	q = `
+		SELECT fingerprint, status_code, content_type, response_body
+		FROM idempotency_keys
+		WHERE scope = $1 AND key = $2;
+	`
+	rec := &IdempotencyRecord{}
+	var statusCode sql.NullInt64
+	var contentType sql.NullString
+	err = d.db.DB().QueryRowContext(ctx, q, scope, key).Scan(&rec.Fingerprint, &statusCode, &contentType, &rec.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	rec.StatusCode = int(statusCode.Int64)
+	rec.ContentType = contentType.String
+	return rec, false, nil
+}
+
+// Complete implements IdempotencyStore.
+func (d *dbIdempotencyStore) Complete(ctx context.Context, scope, key string, rec *IdempotencyRecord) error {
+	q := `
+		UPDATE idempotency_keys
+		SET status_code = $1, content_type = $2, response_body = $3
+		WHERE scope = $4 AND key = $5;
+	`
+	_, err := d.db.DB().ExecContext(ctx, q, rec.StatusCode, rec.ContentType, rec.Body, scope, key)
+	return err
+}
+
+// Release implements IdempotencyStore.
+func (d *dbIdempotencyStore) Release(ctx context.Context, scope, key string) error {
+	q := `
+		DELETE FROM idempotency_keys
+		WHERE scope = $1 AND key = $2;
+	`
+	_, err := d.db.DB().ExecContext(ctx, q, scope, key)
+	return err
+}
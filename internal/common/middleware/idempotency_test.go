@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memoryIdempotencyStore keeps idempotency records in memory.
+type memoryIdempotencyStore struct {
+	records map[string]*IdempotencyRecord
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]*IdempotencyRecord)}
+}
+
+func (m *memoryIdempotencyStore) Reserve(ctx context.Context, scope, key, fingerprint string, ttl time.Duration) (*IdempotencyRecord, bool, error) {
+	if rec, ok := m.records[scope+key]; ok {
+		return rec, false, nil
+	}
+	m.records[scope+key] = &IdempotencyRecord{Fingerprint: fingerprint}
+	return nil, true, nil
+}
+
+func (m *memoryIdempotencyStore) Complete(ctx context.Context, scope, key string, rec *IdempotencyRecord) error {
+	rec.Fingerprint = m.records[scope+key].Fingerprint
+	m.records[scope+key] = rec
+	return nil
+}
+
+func (m *memoryIdempotencyStore) Release(ctx context.Context, scope, key string) error {
+	delete(m.records, scope+key)
+	return nil
+}
+
+func TestIdempotencyReleasesKeyOnPanic(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	idempotency := NewIdempotency(store, time.Hour)
+	calls := 0
+	handler := PanicRecoverer(http.HandlerFunc(idempotency.Handle(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		w.WriteHeader(http.StatusCreated)
+	})))
+
+	send := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/v1/product/checkout", strings.NewReader(`{"paid":100}`))
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := send(); code != http.StatusInternalServerError {
+		t.Fatalf("first request status = %d, want %d", code, http.StatusInternalServerError)
+	}
+	if len(store.records) != 0 {
+		t.Fatalf("key is still reserved after a panic")
+	}
+	if code := send(); code != http.StatusCreated {
+		t.Fatalf("retry status = %d, want %d", code, http.StatusCreated)
+	}
+	if code := send(); code != http.StatusCreated || calls != 2 {
+		t.Fatalf("replay status = %d after %d calls, want %d after 2 calls", code, calls, http.StatusCreated)
+	}
+}
@@ -0,0 +1,9 @@
+package giftcard
+
+import "errors"
+
+var (
+	ErrValidationFailed = errors.New("validation failed")
+	ErrCustomerNotFound = errors.New("customer not found")
+	ErrGiftCardNotFound = errors.New("gift card not found")
+)
@@ -0,0 +1,35 @@
+package giftcard
+
+import "time"
+
+// GiftCard is a prepaid balance. Cards sold at the counter are redeemed with
+// their Code, while store credit is a card that belongs to a customer and has
+// no code. Both are redeemed the same way.
+type GiftCard struct {
+	ID         string
+	Code       *string
+	CustomerID *string
+	Balance    int64
+	CreatedAt  time.Time
+}
+
+type TransactionType string
+
+var (
+	TransactionIssue    TransactionType = "Issue"
+	TransactionRedeem   TransactionType = "Redeem"
+	TransactionCredit   TransactionType = "Credit"
+	TransactionReversal TransactionType = "Reversal"
+)
+
+// Transaction is a change to a card's balance. Amount is negative when money
+// is taken off the card.
+type Transaction struct {
+	ID                string
+	GiftCardID        string
+	CheckoutHistoryID *string
+	RefundID          *string
+	Type              TransactionType
+	Amount            int64
+	CreatedAt         time.Time
+}
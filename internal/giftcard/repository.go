@@ -0,0 +1,143 @@
+package giftcard
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/db"
+)
+
+type Repository interface {
+	Issue(ctx context.Context, card *GiftCard, transaction *Transaction) error
+	GetByCode(ctx context.Context, code string) (*GiftCard, error)
+	GetByCustomerID(ctx context.Context, customerID string) (*GiftCard, error)
+	ListTransactions(ctx context.Context, giftCardID string, limit, offset int) ([]*Transaction, error)
+	ListTransactionsByCheckout(ctx context.Context, checkoutHistoryID string) ([]*Transaction, error)
+}
+
+const giftCardColumns = "id, code, customer_id, balance, created_at"
+
+type dbRepository struct {
+	db *db.DB
+}
+
+func NewRepository(db *db.DB) Repository {
+	return &dbRepository{db: db}
+}
+
+// Issue implements Repository.
+// Store credit is added to the customer's existing balance, if any, in which
+// case card takes the ID of the existing card.
+func (d *dbRepository) Issue(ctx context.Context, card *GiftCard, transaction *Transaction) error {
+	return d.db.StartTx(ctx, func(tx *sql.Tx) error {
+		q := `
+			INSERT INTO gift_cards (
+				id, code, customer_id, balance
+			) VALUES (
+				$1, $2, $3, $4
+			)
+			RETURNING ` + giftCardColumns + `;
+		`
+		if card.CustomerID != nil {
+			q = `
+				INSERT INTO gift_cards (
+					id, code, customer_id, balance
+				) VALUES (
+					$1, $2, $3, $4
+				)
+				ON CONFLICT (customer_id) WHERE customer_id IS NOT NULL DO UPDATE
+				SET balance = gift_cards.balance + EXCLUDED.balance
+				RETURNING ` + giftCardColumns + `;
+			`
+		}
+		err := tx.QueryRowContext(ctx, q, card.ID, card.Code, card.CustomerID, transaction.Amount).
+			Scan(&card.ID, &card.Code, &card.CustomerID, &card.Balance, &card.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		transaction.GiftCardID = card.ID
+		q = `
+			INSERT INTO gift_card_transactions (
+				id, gift_card_id, type, amount
+			) VALUES (
+				$1, $2, $3, $4
+			) RETURNING created_at;
+		`
+		return tx.QueryRowContext(ctx, q, transaction.ID, transaction.GiftCardID, transaction.Type, transaction.Amount).Scan(&transaction.CreatedAt)
+	})
+}
+
+// GetByCode implements Repository.
+func (d *dbRepository) GetByCode(ctx context.Context, code string) (*GiftCard, error) {
+	q := `
+		SELECT ` + giftCardColumns + `
+		FROM gift_cards
+		WHERE code = $1;
+	`
+	return d.get(ctx, q, code)
+}
+
+// GetByCustomerID implements Repository.
+func (d *dbRepository) GetByCustomerID(ctx context.Context, customerID string) (*GiftCard, error) {
+	q := `
+		SELECT ` + giftCardColumns + `
+		FROM gift_cards
+		WHERE customer_id = $1;
+	`
+	return d.get(ctx, q, customerID)
+}
+
+func (d *dbRepository) get(ctx context.Context, q string, args ...any) (*GiftCard, error) {
+	card := &GiftCard{}
+	err := d.db.DB().QueryRowContext(ctx, q, args...).Scan(&card.ID, &card.Code, &card.CustomerID, &card.Balance, &card.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrGiftCardNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+// ListTransactions implements Repository.
+func (d *dbRepository) ListTransactions(ctx context.Context, giftCardID string, limit, offset int) ([]*Transaction, error) {
+	q := `
+		SELECT id, gift_card_id, checkout_history_id, refund_id, type, amount, created_at
+		FROM gift_card_transactions
+		WHERE gift_card_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3;
+	`
+	return d.listTransactions(ctx, q, giftCardID, limit, offset)
+}
+
+// ListTransactionsByCheckout implements Repository.
+func (d *dbRepository) ListTransactionsByCheckout(ctx context.Context, checkoutHistoryID string) ([]*Transaction, error) {
+	q := `
+		SELECT id, gift_card_id, checkout_history_id, refund_id, type, amount, created_at
+		FROM gift_card_transactions
+		WHERE checkout_history_id = $1
+		ORDER BY created_at ASC;
+	`
+	return d.listTransactions(ctx, q, checkoutHistoryID)
+}
+
+func (d *dbRepository) listTransactions(ctx context.Context, q string, args ...any) ([]*Transaction, error) {
+	rows, err := d.db.DB().QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*Transaction, 0)
+	for rows.Next() {
+		t := &Transaction{}
+		err := rows.Scan(&t.ID, &t.GiftCardID, &t.CheckoutHistoryID, &t.RefundID, &t.Type, &t.Amount, &t.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	return res, rows.Err()
+}
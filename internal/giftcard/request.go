@@ -0,0 +1,55 @@
+package giftcard
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type IssueGiftCardPayload struct {
+	Amount int64 `json:"amount"`
+}
+
+func (p IssueGiftCardPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Amount, validation.Required, validation.Min(1)),
+	)
+}
+
+type IssueStoreCreditPayload struct {
+	CustomerID string `json:"-"`
+	Amount     int64  `json:"amount"`
+}
+
+func (p IssueStoreCreditPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.CustomerID, validation.Required),
+		validation.Field(&p.Amount, validation.Required, validation.Min(1)),
+	)
+}
+
+type GetGiftCardPayload struct {
+	Code   string `schema:"-"`
+	Limit  int    `schema:"limit" binding:"omitempty"`
+	Offset int    `schema:"offset" binding:"omitempty"`
+}
+
+func (p GetGiftCardPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Code, validation.Required),
+		validation.Field(&p.Limit, validation.Min(0)),
+		validation.Field(&p.Offset, validation.Min(0)),
+	)
+}
+
+type GetStoreCreditPayload struct {
+	CustomerID string `schema:"-"`
+	Limit      int    `schema:"limit" binding:"omitempty"`
+	Offset     int    `schema:"offset" binding:"omitempty"`
+}
+
+func (p GetStoreCreditPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.CustomerID, validation.Required),
+		validation.Field(&p.Limit, validation.Min(0)),
+		validation.Field(&p.Offset, validation.Min(0)),
+	)
+}
@@ -0,0 +1,20 @@
+package giftcard
+
+import "time"
+
+type GiftCardResponse struct {
+	Code         *string               `json:"code"`
+	CustomerID   *string               `json:"customerId"`
+	Balance      int64                 `json:"balance"`
+	Transactions []TransactionResponse `json:"transactions"`
+	CreatedAt    time.Time             `json:"createdAt"`
+}
+
+type TransactionResponse struct {
+	ID            string          `json:"id"`
+	TransactionID *string         `json:"transactionId"`
+	RefundID      *string         `json:"refundId"`
+	Type          TransactionType `json:"type"`
+	Amount        int64           `json:"amount"`
+	CreatedAt     time.Time       `json:"createdAt"`
+}
@@ -0,0 +1,157 @@
+package giftcard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/id"
+	"github.com/citadel-corp/eniqilo-store/internal/user"
+)
+
+type Service interface {
+	IssueGiftCard(ctx context.Context, req IssueGiftCardPayload) (*GiftCardResponse, error)
+	GetGiftCard(ctx context.Context, req GetGiftCardPayload) (*GiftCardResponse, error)
+	IssueStoreCredit(ctx context.Context, req IssueStoreCreditPayload) (*GiftCardResponse, error)
+	GetStoreCredit(ctx context.Context, req GetStoreCreditPayload) (*GiftCardResponse, error)
+}
+
+type giftCardService struct {
+	repository     Repository
+	userRepository user.Repository
+}
+
+func NewService(repository Repository, userRepository user.Repository) Service {
+	return &giftCardService{
+		repository:     repository,
+		userRepository: userRepository,
+	}
+}
+
+// IssueGiftCard implements Service.
+func (s *giftCardService) IssueGiftCard(ctx context.Context, req IssueGiftCardPayload) (*GiftCardResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	code := id.GenerateStringID(16)
+	card := &GiftCard{
+		ID:   id.GenerateStringID(16),
+		Code: &code,
+	}
+	transaction := &Transaction{
+		ID:     id.GenerateStringID(16),
+		Type:   TransactionIssue,
+		Amount: req.Amount,
+	}
+	err := s.repository.Issue(ctx, card, transaction)
+	if err != nil {
+		return nil, err
+	}
+	return toGiftCardResponse(card, []*Transaction{transaction}), nil
+}
+
+// GetGiftCard implements Service.
+func (s *giftCardService) GetGiftCard(ctx context.Context, req GetGiftCardPayload) (*GiftCardResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+	card, err := s.repository.GetByCode(ctx, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := s.repository.ListTransactions(ctx, card.ID, req.Limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return toGiftCardResponse(card, transactions), nil
+}
+
+// IssueStoreCredit implements Service.
+func (s *giftCardService) IssueStoreCredit(ctx context.Context, req IssueStoreCreditPayload) (*GiftCardResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	customer, err := s.getCustomer(ctx, req.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	card := &GiftCard{
+		ID:         id.GenerateStringID(16),
+		CustomerID: &customer.ID,
+	}
+	transaction := &Transaction{
+		ID:     id.GenerateStringID(16),
+		Type:   TransactionCredit,
+		Amount: req.Amount,
+	}
+	err = s.repository.Issue(ctx, card, transaction)
+	if err != nil {
+		return nil, err
+	}
+	return toGiftCardResponse(card, []*Transaction{transaction}), nil
+}
+
+// GetStoreCredit implements Service.
+// A customer who was never given store credit has a zero balance.
+func (s *giftCardService) GetStoreCredit(ctx context.Context, req GetStoreCreditPayload) (*GiftCardResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+	customer, err := s.getCustomer(ctx, req.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	card, err := s.repository.GetByCustomerID(ctx, customer.ID)
+	if errors.Is(err, ErrGiftCardNotFound) {
+		return toGiftCardResponse(&GiftCard{CustomerID: &customer.ID}, nil), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := s.repository.ListTransactions(ctx, card.ID, req.Limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return toGiftCardResponse(card, transactions), nil
+}
+
+func (s *giftCardService) getCustomer(ctx context.Context, customerID string) (*user.User, error) {
+	customer, err := s.userRepository.GetByID(ctx, customerID)
+	if errors.Is(err, user.ErrUserNotFound) {
+		return nil, ErrCustomerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if customer.UserType != user.Customer {
+		return nil, ErrCustomerNotFound
+	}
+	return customer, nil
+}
+
+func toGiftCardResponse(card *GiftCard, transactions []*Transaction) *GiftCardResponse {
+	res := &GiftCardResponse{
+		Code:         card.Code,
+		CustomerID:   card.CustomerID,
+		Balance:      card.Balance,
+		Transactions: make([]TransactionResponse, len(transactions)),
+		CreatedAt:    card.CreatedAt,
+	}
+	for i, t := range transactions {
+		res.Transactions[i] = TransactionResponse{
+			ID:            t.ID,
+			TransactionID: t.CheckoutHistoryID,
+			RefundID:      t.RefundID,
+			Type:          t.Type,
+			Amount:        t.Amount,
+			CreatedAt:     t.CreatedAt,
+		}
+	}
+	return res
+}
@@ -0,0 +1,9 @@
+package location
+
+import "errors"
+
+var (
+	ErrValidationFailed = errors.New("validation failed")
+	ErrLocationNotFound = errors.New("location not found")
+	ErrNameTaken        = errors.New("location name is already taken")
+)
@@ -0,0 +1,72 @@
+package location
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/request"
+	"github.com/citadel-corp/eniqilo-store/internal/common/response"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) CreateLocation(w http.ResponseWriter, r *http.Request) {
+	var req CreateLocationPayload
+
+	err := request.DecodeJSON(w, r, &req)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Failed to decode JSON",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	location, err := h.service.Create(r.Context(), req)
+	if errors.Is(err, ErrNameTaken) {
+		response.JSON(w, http.StatusConflict, response.ResponseBody{
+			Message: "Location already exists",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, ErrValidationFailed) {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	response.JSON(w, http.StatusCreated, response.ResponseBody{
+		Message: "Location created successfully",
+		Data:    location,
+	})
+}
+
+func (h *Handler) ListLocations(w http.ResponseWriter, r *http.Request) {
+	locations, err := h.service.List(r.Context())
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "success",
+		Data:    locations,
+	})
+}
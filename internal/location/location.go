@@ -0,0 +1,15 @@
+package location
+
+import "time"
+
+// DefaultID is the location that stock is kept at when no location is given.
+// Stock from before locations were introduced was migrated into it.
+const DefaultID = "default"
+
+// Location is a place stock is kept, such as the shop floor, the back room
+// or a branch.
+type Location struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+}
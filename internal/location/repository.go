@@ -0,0 +1,102 @@
+package location
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/db"
+)
+
+type Repository interface {
+	Create(ctx context.Context, location *Location) error
+	GetByID(ctx context.Context, id string) (*Location, error)
+	GetByName(ctx context.Context, name string) (*Location, error)
+	List(ctx context.Context) ([]*Location, error)
+}
+
+type dbRepository struct {
+	db *db.DB
+}
+
+func NewRepository(db *db.DB) Repository {
+	return &dbRepository{db: db}
+}
+
+// Create implements Repository.
+func (d *dbRepository) Create(ctx context.Context, location *Location) error {
+	q := `
+		INSERT INTO locations (
+			id, name
+		) VALUES (
+			$1, $2
+		)
+		ON CONFLICT (name) DO NOTHING
+		RETURNING created_at;
+	`
+	err := d.db.DB().QueryRowContext(ctx, q, location.ID, location.Name).Scan(&location.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNameTaken
+	}
+	return err
+}
+
+// GetByID implements Repository.
+func (d *dbRepository) GetByID(ctx context.Context, id string) (*Location, error) {
+	q := `
+		SELECT id, name, created_at
+		FROM locations
+		WHERE id = $1;
+	`
+	l := &Location{}
+	err := d.db.DB().QueryRowContext(ctx, q, id).Scan(&l.ID, &l.Name, &l.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLocationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// GetByName implements Repository.
+func (d *dbRepository) GetByName(ctx context.Context, name string) (*Location, error) {
+	q := `
+		SELECT id, name, created_at
+		FROM locations
+		WHERE name = $1;
+	`
+	l := &Location{}
+	err := d.db.DB().QueryRowContext(ctx, q, name).Scan(&l.ID, &l.Name, &l.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrLocationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// List implements Repository.
+func (d *dbRepository) List(ctx context.Context) ([]*Location, error) {
+	q := `
+		SELECT id, name, created_at
+		FROM locations
+		ORDER BY name;
+	`
+	rows, err := d.db.DB().QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*Location, 0)
+	for rows.Next() {
+		l := &Location{}
+		err := rows.Scan(&l.ID, &l.Name, &l.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, l)
+	}
+	return res, rows.Err()
+}
@@ -0,0 +1,15 @@
+package location
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type CreateLocationPayload struct {
+	Name string `json:"name"`
+}
+
+func (p CreateLocationPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Name, validation.Required, validation.Length(1, 50)),
+	)
+}
@@ -0,0 +1,9 @@
+package location
+
+import "time"
+
+type LocationResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,58 @@
+package location
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/id"
+)
+
+type Service interface {
+	Create(ctx context.Context, req CreateLocationPayload) (*LocationResponse, error)
+	List(ctx context.Context) ([]*LocationResponse, error)
+}
+
+type locationService struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &locationService{repository: repository}
+}
+
+// Create implements Service.
+func (s *locationService) Create(ctx context.Context, req CreateLocationPayload) (*LocationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	location := &Location{
+		ID:   id.GenerateStringID(16),
+		Name: req.Name,
+	}
+	err := s.repository.Create(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+	return toLocationResponse(location), nil
+}
+
+// List implements Service.
+func (s *locationService) List(ctx context.Context) ([]*LocationResponse, error) {
+	locations, err := s.repository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*LocationResponse, len(locations))
+	for i, location := range locations {
+		res[i] = toLocationResponse(location)
+	}
+	return res, nil
+}
+
+func toLocationResponse(location *Location) *LocationResponse {
+	return &LocationResponse{
+		ID:        location.ID,
+		Name:      location.Name,
+		CreatedAt: location.CreatedAt,
+	}
+}
@@ -0,0 +1,11 @@
+package loyalty
+
+import "errors"
+
+var (
+	ErrValidationFailed   = errors.New("validation failed")
+	ErrCustomerNotFound   = errors.New("customer not found")
+	ErrMultiplierNotFound = errors.New("loyalty multiplier not found")
+	ErrNotEnoughPoints    = errors.New("not enough loyalty points")
+	ErrRedemptionNotWhole = errors.New("redeemed amount must be a whole number of points")
+)
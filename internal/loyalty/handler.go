@@ -0,0 +1,143 @@
+package loyalty
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/request"
+	"github.com/citadel-corp/eniqilo-store/internal/common/response"
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/schema"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+func (h *Handler) GetAccount(w http.ResponseWriter, r *http.Request) {
+	newSchema := schema.NewDecoder()
+	newSchema.IgnoreUnknownKeys(true)
+
+	var req GetAccountPayload
+	if err := newSchema.Decode(&req, r.URL.Query()); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	req.CustomerID = mux.Vars(r)["id"]
+
+	account, err := h.service.GetAccount(r.Context(), req)
+	if errors.Is(err, ErrCustomerNotFound) {
+		response.JSON(w, http.StatusNotFound, response.ResponseBody{
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, ErrValidationFailed) {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "success",
+		Data:    account,
+	})
+}
+
+func (h *Handler) SetMultiplier(w http.ResponseWriter, r *http.Request) {
+	var req SetMultiplierPayload
+
+	err := request.DecodeJSON(w, r, &req)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Failed to decode JSON",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	multiplier, err := h.service.SetMultiplier(r.Context(), req)
+	if errors.Is(err, ErrValidationFailed) {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "Loyalty multiplier set successfully",
+		Data:    multiplier,
+	})
+}
+
+func (h *Handler) ListMultipliers(w http.ResponseWriter, r *http.Request) {
+	multipliers, err := h.service.ListMultipliers(r.Context())
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "success",
+		Data:    multipliers,
+	})
+}
+
+func (h *Handler) DeleteMultiplier(w http.ResponseWriter, r *http.Request) {
+	var req DeleteMultiplierPayload
+
+	params := mux.Vars(r)
+	req.Category = product.ProductCategory(params["category"])
+
+	err := h.service.DeleteMultiplier(r.Context(), req)
+	if errors.Is(err, ErrMultiplierNotFound) {
+		response.JSON(w, http.StatusNotFound, response.ResponseBody{
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, ErrValidationFailed) {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "Loyalty multiplier deleted successfully",
+	})
+}
@@ -0,0 +1,100 @@
+package loyalty
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+var (
+	earnAmountStr = os.Getenv("LOYALTY_EARN_AMOUNT")
+	pointValueStr = os.Getenv("LOYALTY_POINT_VALUE")
+)
+
+// EarnAmount is the amount a customer has to spend to earn one point. It is
+// controlled by LOYALTY_EARN_AMOUNT and defaults to 1000.
+func EarnAmount() int64 {
+	earnAmount, err := strconv.ParseInt(earnAmountStr, 10, 64)
+	if err != nil || earnAmount <= 0 {
+		return 1000
+	}
+	return earnAmount
+}
+
+// PointValue is the amount a single point is worth when redeemed. It is
+// controlled by LOYALTY_POINT_VALUE and defaults to 1.
+func PointValue() int64 {
+	pointValue, err := strconv.ParseInt(pointValueStr, 10, 64)
+	if err != nil || pointValue <= 0 {
+		return 1
+	}
+	return pointValue
+}
+
+// ToPoints returns the points needed to redeem amount. Only whole points can
+// be redeemed.
+func ToPoints(amount int64) (int, error) {
+	pointValue := PointValue()
+	if amount%pointValue != 0 {
+		return 0, ErrRedemptionNotWhole
+	}
+	return int(amount / pointValue), nil
+}
+
+// Multiplier scales the points earned on a product category, as a percentage
+// (150 earns one and a half times the points). Categories without a
+// multiplier earn at 100.
+type Multiplier struct {
+	Category   product.ProductCategory
+	Multiplier int
+	CreatedAt  time.Time
+}
+
+type Rules struct {
+	earnAmount int64
+	byCategory map[product.ProductCategory]int
+}
+
+func NewRules(multipliers []*Multiplier) *Rules {
+	r := &Rules{
+		earnAmount: EarnAmount(),
+		byCategory: make(map[product.ProductCategory]int),
+	}
+	for _, multiplier := range multipliers {
+		r.byCategory[multiplier.Category] = multiplier.Multiplier
+	}
+	return r
+}
+
+// Points returns the points earned by spending amount on a product of the
+// given category.
+func (r *Rules) Points(amount int64, category product.ProductCategory) int {
+	multiplier, ok := r.byCategory[category]
+	if !ok {
+		multiplier = 100
+	}
+	return int(amount * int64(multiplier) / 100 / r.earnAmount)
+}
+
+type EntryType string
+
+var (
+	EntryEarn     EntryType = "Earn"
+	EntryRedeem   EntryType = "Redeem"
+	EntryClawback EntryType = "Clawback"
+	EntryReversal EntryType = "Reversal"
+)
+
+// Entry is a change to a customer's points balance. Points is negative when
+// points are taken off the balance.
+type Entry struct {
+	ID                string
+	UserID            string
+	CheckoutHistoryID *string
+	RefundID          *string
+	Type              EntryType
+	Points            int
+	CreatedAt         time.Time
+}
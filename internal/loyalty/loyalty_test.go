@@ -0,0 +1,59 @@
+package loyalty
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+func TestPoints(t *testing.T) {
+	rules := &Rules{
+		earnAmount: 1000,
+		byCategory: map[product.ProductCategory]int{
+			product.CategoryFootwear:  150,
+			product.CategoryBeverages: 0,
+		},
+	}
+	tests := []struct {
+		amount   int64
+		category product.ProductCategory
+		want     int
+	}{
+		{amount: 10000, category: product.CategoryClothing, want: 10},
+		// partial points are not earned
+		{amount: 1999, category: product.CategoryClothing, want: 1},
+		{amount: 999, category: product.CategoryClothing, want: 0},
+		{amount: 10000, category: product.CategoryFootwear, want: 15},
+		// 1.5 times 1999 is 2998.5, still two points
+		{amount: 1999, category: product.CategoryFootwear, want: 2},
+		{amount: 10000, category: product.CategoryBeverages, want: 0},
+	}
+	for _, tt := range tests {
+		if got := rules.Points(tt.amount, tt.category); got != tt.want {
+			t.Errorf("Points(%d, %s) = %d, want %d", tt.amount, tt.category, got, tt.want)
+		}
+	}
+}
+
+func TestToPoints(t *testing.T) {
+	defer func(s string) { pointValueStr = s }(pointValueStr)
+
+	pointValueStr = ""
+	if got, err := ToPoints(250); got != 250 || err != nil {
+		t.Errorf("default point value: ToPoints(250) = %d, %v; want 250, nil", got, err)
+	}
+
+	pointValueStr = "100"
+	if got, err := ToPoints(500); got != 5 || err != nil {
+		t.Errorf("ToPoints(500) = %d, %v; want 5, nil", got, err)
+	}
+	if _, err := ToPoints(550); !errors.Is(err, ErrRedemptionNotWhole) {
+		t.Errorf("ToPoints(550) err = %v, want %v", err, ErrRedemptionNotWhole)
+	}
+
+	pointValueStr = "-5"
+	if got, err := ToPoints(7); got != 7 || err != nil {
+		t.Errorf("invalid point value: ToPoints(7) = %d, %v; want 7, nil", got, err)
+	}
+}
@@ -0,0 +1,129 @@
+package loyalty
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/db"
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+type Repository interface {
+	GetBalance(ctx context.Context, userID string) (int, error)
+	ListEntries(ctx context.Context, userID string, limit, offset int) ([]*Entry, error)
+	SetMultiplier(ctx context.Context, multiplier *Multiplier) error
+	ListMultipliers(ctx context.Context) ([]*Multiplier, error)
+	DeleteMultiplier(ctx context.Context, category product.ProductCategory) error
+}
+
+type dbRepository struct {
+	db *db.DB
+}
+
+func NewRepository(db *db.DB) Repository {
+	return &dbRepository{db: db}
+}
+
+// GetBalance implements Repository.
+func (d *dbRepository) GetBalance(ctx context.Context, userID string) (int, error) {
+	q := `
+		SELECT loyalty_points
+		FROM users
+		WHERE id = $1 AND user_type = 'Customer';
+	`
+	var balance int
+	err := d.db.DB().QueryRowContext(ctx, q, userID).Scan(&balance)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrCustomerNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	return balance, nil
+}
+
+// ListEntries implements Repository.
+func (d *dbRepository) ListEntries(ctx context.Context, userID string, limit, offset int) ([]*Entry, error) {
+	q := `
+		SELECT id, user_id, checkout_history_id, refund_id, type, points, created_at
+		FROM loyalty_entries
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3;
+	`
+	rows, err := d.db.DB().QueryContext(ctx, q, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*Entry, 0)
+	for rows.Next() {
+		e := &Entry{}
+		err := rows.Scan(&e.ID, &e.UserID, &e.CheckoutHistoryID, &e.RefundID, &e.Type, &e.Points, &e.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, e)
+	}
+	return res, rows.Err()
+}
+
+// SetMultiplier implements Repository.
+// An existing multiplier for the same category is replaced.
+func (d *dbRepository) SetMultiplier(ctx context.Context, multiplier *Multiplier) error {
+	q := `
+		INSERT INTO loyalty_multipliers (
+			category, multiplier
+		) VALUES (
+			$1, $2
+		)
+		ON CONFLICT (category) DO UPDATE SET multiplier = EXCLUDED.multiplier
+		RETURNING created_at;
+	`
+	return d.db.DB().QueryRowContext(ctx, q, multiplier.Category, multiplier.Multiplier).Scan(&multiplier.CreatedAt)
+}
+
+// ListMultipliers implements Repository.
+func (d *dbRepository) ListMultipliers(ctx context.Context) ([]*Multiplier, error) {
+	q := `
+		SELECT category, multiplier, created_at
+		FROM loyalty_multipliers
+		ORDER BY category ASC;
+	`
+	rows, err := d.db.DB().QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*Multiplier, 0)
+	for rows.Next() {
+		m := &Multiplier{}
+		err := rows.Scan(&m.Category, &m.Multiplier, &m.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, m)
+	}
+	return res, rows.Err()
+}
+
+// DeleteMultiplier implements Repository.
+func (d *dbRepository) DeleteMultiplier(ctx context.Context, category product.ProductCategory) error {
+	q := `
+		DELETE FROM loyalty_multipliers
+		WHERE category = $1;
+	`
+	row, err := d.db.DB().ExecContext(ctx, q, category)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := row.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrMultiplierNotFound
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package loyalty
+
+import (
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type SetMultiplierPayload struct {
+	Category product.ProductCategory `json:"category"`
+	// Multiplier is a percentage, e.g. 200 to earn double points.
+	Multiplier *int `json:"multiplier"`
+}
+
+func (p SetMultiplierPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Category, validation.Required, validation.In(product.ProductCategories...)),
+		validation.Field(&p.Multiplier, validation.NotNil, validation.Min(0)),
+	)
+}
+
+type DeleteMultiplierPayload struct {
+	Category product.ProductCategory
+}
+
+func (p DeleteMultiplierPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Category, validation.Required, validation.In(product.ProductCategories...)),
+	)
+}
+
+type GetAccountPayload struct {
+	CustomerID string `schema:"-"`
+	Limit      int    `schema:"limit" binding:"omitempty"`
+	Offset     int    `schema:"offset" binding:"omitempty"`
+}
+
+func (p GetAccountPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.CustomerID, validation.Required),
+		validation.Field(&p.Limit, validation.Min(0)),
+		validation.Field(&p.Offset, validation.Min(0)),
+	)
+}
@@ -0,0 +1,28 @@
+package loyalty
+
+import (
+	"time"
+
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+type MultiplierResponse struct {
+	Category   product.ProductCategory `json:"category"`
+	Multiplier int                     `json:"multiplier"`
+	CreatedAt  time.Time               `json:"createdAt"`
+}
+
+type AccountResponse struct {
+	CustomerID string          `json:"customerId"`
+	Balance    int             `json:"balance"`
+	Entries    []EntryResponse `json:"entries"`
+}
+
+type EntryResponse struct {
+	ID            string    `json:"id"`
+	TransactionID *string   `json:"transactionId"`
+	RefundID      *string   `json:"refundId"`
+	Type          EntryType `json:"type"`
+	Points        int       `json:"points"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
@@ -0,0 +1,100 @@
+package loyalty
+
+import (
+	"context"
+	"fmt"
+)
+
+type Service interface {
+	GetAccount(ctx context.Context, req GetAccountPayload) (*AccountResponse, error)
+	SetMultiplier(ctx context.Context, req SetMultiplierPayload) (*MultiplierResponse, error)
+	ListMultipliers(ctx context.Context) ([]*MultiplierResponse, error)
+	DeleteMultiplier(ctx context.Context, req DeleteMultiplierPayload) error
+}
+
+type loyaltyService struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &loyaltyService{repository: repository}
+}
+
+// GetAccount implements Service.
+func (s *loyaltyService) GetAccount(ctx context.Context, req GetAccountPayload) (*AccountResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+	balance, err := s.repository.GetBalance(ctx, req.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.repository.ListEntries(ctx, req.CustomerID, req.Limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+	res := &AccountResponse{
+		CustomerID: req.CustomerID,
+		Balance:    balance,
+		Entries:    make([]EntryResponse, len(entries)),
+	}
+	for i, e := range entries {
+		res.Entries[i] = EntryResponse{
+			ID:            e.ID,
+			TransactionID: e.CheckoutHistoryID,
+			RefundID:      e.RefundID,
+			Type:          e.Type,
+			Points:        e.Points,
+			CreatedAt:     e.CreatedAt,
+		}
+	}
+	return res, nil
+}
+
+// SetMultiplier implements Service.
+func (s *loyaltyService) SetMultiplier(ctx context.Context, req SetMultiplierPayload) (*MultiplierResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	multiplier := &Multiplier{
+		Category:   req.Category,
+		Multiplier: *req.Multiplier,
+	}
+	err := s.repository.SetMultiplier(ctx, multiplier)
+	if err != nil {
+		return nil, err
+	}
+	return toMultiplierResponse(multiplier), nil
+}
+
+// ListMultipliers implements Service.
+func (s *loyaltyService) ListMultipliers(ctx context.Context) ([]*MultiplierResponse, error) {
+	multipliers, err := s.repository.ListMultipliers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*MultiplierResponse, len(multipliers))
+	for i, multiplier := range multipliers {
+		res[i] = toMultiplierResponse(multiplier)
+	}
+	return res, nil
+}
+
+// DeleteMultiplier implements Service.
+func (s *loyaltyService) DeleteMultiplier(ctx context.Context, req DeleteMultiplierPayload) error {
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	return s.repository.DeleteMultiplier(ctx, req.Category)
+}
+
+func toMultiplierResponse(multiplier *Multiplier) *MultiplierResponse {
+	return &MultiplierResponse{
+		Category:   multiplier.Category,
+		Multiplier: multiplier.Multiplier,
+		CreatedAt:  multiplier.CreatedAt,
+	}
+}
@@ -5,4 +5,6 @@ import "errors"
 var (
 	ErrValidationFailed = errors.New("validation failed")
 	ErrProductNotFound  = errors.New("product not found")
+	ErrStockNotEnough   = errors.New("product stock is not enough")
+	ErrLocationNotFound = errors.New("location not found")
 )
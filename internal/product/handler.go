@@ -1,8 +1,10 @@
 package product
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/citadel-corp/eniqilo-store/internal/common/middleware"
 	"github.com/citadel-corp/eniqilo-store/internal/common/request"
 	"github.com/citadel-corp/eniqilo-store/internal/common/response"
 	"github.com/gorilla/mux"
@@ -39,6 +41,13 @@ func (h *Handler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 	}
 
 	userResp, err := h.service.Create(r.Context(), req)
+	if errors.Is(err, ErrLocationNotFound) {
+		response.JSON(w, http.StatusNotFound, response.ResponseBody{
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
 			Message: "Internal server error",
@@ -185,3 +194,126 @@ func (h *Handler) ListProductForCustomer(w http.ResponseWriter, r *http.Request)
 		Data:    products,
 	})
 }
+
+func (h *Handler) ListLowStock(w http.ResponseWriter, r *http.Request) {
+	var req ListLowStockPayload
+
+	newSchema := schema.NewDecoder()
+	newSchema.IgnoreUnknownKeys(true)
+
+	if err := newSchema.Decode(&req, r.URL.Query()); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{})
+		return
+	}
+
+	products, err := h.service.ListLowStock(r.Context(), req)
+	if errors.Is(err, ErrValidationFailed) {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "Products fetched successfully",
+		Data:    products,
+	})
+}
+
+func (h *Handler) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	var req StockAdjustmentPayload
+
+	err := request.DecodeJSON(w, r, &req)
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Failed to decode JSON",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	params := mux.Vars(r)
+	req.ProductID = params["id"]
+	req.StaffID, _ = r.Context().Value(middleware.ContextAuthKey{}).(string)
+
+	err = req.Validate()
+	if err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	movement, err := h.service.AdjustStock(r.Context(), req)
+	if errors.Is(err, ErrProductNotFound) ||
+		errors.Is(err, ErrLocationNotFound) {
+		response.JSON(w, http.StatusNotFound, response.ResponseBody{
+			Message: "Not found",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if errors.Is(err, ErrStockNotEnough) {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, response.ResponseBody{
+		Message: "Stock adjusted successfully",
+		Data:    movement,
+	})
+}
+
+func (h *Handler) ListStockMovements(w http.ResponseWriter, r *http.Request) {
+	var req ListStockMovementsPayload
+
+	newSchema := schema.NewDecoder()
+	newSchema.IgnoreUnknownKeys(true)
+
+	if err := newSchema.Decode(&req, r.URL.Query()); err != nil {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{})
+		return
+	}
+	params := mux.Vars(r)
+	req.ProductID = params["id"]
+
+	movements, err := h.service.ListStockMovements(r.Context(), req)
+	if errors.Is(err, ErrValidationFailed) {
+		response.JSON(w, http.StatusBadRequest, response.ResponseBody{
+			Message: "Bad request",
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		response.JSON(w, http.StatusInternalServerError, response.ResponseBody{
+			Message: "Internal server error",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	response.JSON(w, http.StatusOK, response.ResponseBody{
+		Message: "Stock movements fetched successfully",
+		Data:    movements,
+	})
+}
@@ -0,0 +1,73 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// LowStockAlert is raised when a sale takes a product's stock to or below its
+// reorder point. It is raised once until the stock is replenished.
+type LowStockAlert struct {
+	ProductID    string    `json:"productId"`
+	Name         string    `json:"name"`
+	SKU          string    `json:"sku"`
+	Stock        int       `json:"stock"`
+	ReorderPoint int       `json:"reorderPoint"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Notifier delivers low stock alerts to whoever reorders stock.
+type Notifier interface {
+	NotifyLowStock(ctx context.Context, alert LowStockAlert) error
+}
+
+type logNotifier struct{}
+
+// NewLogNotifier returns a Notifier that writes alerts to the service log.
+func NewLogNotifier() Notifier {
+	return &logNotifier{}
+}
+
+// NotifyLowStock implements Notifier.
+func (n *logNotifier) NotifyLowStock(ctx context.Context, alert LowStockAlert) error {
+	log.Warn().Msg(fmt.Sprintf("Low stock: %s (%s) has %d left, reorder point is %d", alert.Name, alert.SKU, alert.Stock, alert.ReorderPoint))
+	return nil
+}
+
+type fileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileNotifier returns a Notifier that appends alerts to the file at path
+// as JSON lines.
+func NewFileNotifier(path string) Notifier {
+	return &fileNotifier{path: path}
+}
+
+// NotifyLowStock implements Notifier.
+func (n *fileNotifier) NotifyLowStock(ctx context.Context, alert LowStockAlert) error {
+	b, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
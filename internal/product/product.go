@@ -1,6 +1,8 @@
 package product
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"time"
 )
@@ -25,16 +27,82 @@ func ParseProductCategory(str string) (ProductCategory, error) {
 	return ProductCategory(""), errors.New("not a product category")
 }
 
+// Product is something for sale. Stock is the sellable stock over all
+// locations and Locations breaks it down per location.
 type Product struct {
-	ID          string          `json:"id"`
-	Name        string          `json:"name"`
-	SKU         string          `json:"sku"`
-	Category    ProductCategory `json:"category"`
-	ImageURL    string          `json:"imageUrl"`
-	Notes       string          `json:"notes"`
-	Price       int64           `json:"price"`
-	Stock       int             `json:"stock"`
-	Location    string          `json:"location"`
-	IsAvailable bool            `json:"isAvailable"`
-	CreatedAt   time.Time       `json:"createdAt"`
+	ID           string          `json:"id"`
+	Name         string          `json:"name"`
+	SKU          string          `json:"sku"`
+	Category     ProductCategory `json:"category"`
+	ImageURL     string          `json:"imageUrl"`
+	Notes        string          `json:"notes"`
+	Price        int64           `json:"price"`
+	Stock        int             `json:"stock"`
+	DamagedStock int             `json:"damagedStock"`
+	ReorderPoint *int            `json:"reorderPoint"`
+	OnOrder      int             `json:"onOrder"`
+	Locations    LocationStocks  `json:"locations"`
+	IsAvailable  bool            `json:"isAvailable"`
+	CreatedAt    time.Time       `json:"createdAt"`
+}
+
+// LocationStock is the sellable stock of a product at one location.
+type LocationStock struct {
+	LocationID string `json:"locationId"`
+	Stock      int    `json:"stock"`
+}
+
+type LocationStocks []LocationStock
+
+// StockAt returns the sellable stock of the product at locationID.
+func (p *Product) StockAt(locationID string) int {
+	for _, ls := range p.Locations {
+		if ls.LocationID == locationID {
+			return ls.Stock
+		}
+	}
+	return 0
+}
+
+func (a LocationStocks) Value() (driver.Value, error) {
+	return json.Marshal(a)
+}
+
+func (a *LocationStocks) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &a)
+}
+
+type MovementReason string
+
+var (
+	MovementSale       MovementReason = "Sale"
+	MovementRefund     MovementReason = "Refund"
+	MovementVoid       MovementReason = "Void"
+	MovementAdjustment MovementReason = "Adjustment"
+	MovementReceiving  MovementReason = "Receiving"
+	MovementWriteOff   MovementReason = "WriteOff"
+)
+
+var MovementReasons = []interface{}{MovementSale, MovementRefund, MovementVoid, MovementAdjustment, MovementReceiving, MovementWriteOff}
+
+// StockMovement is a change to a product's sellable stock at a location.
+// Delta is negative when stock is taken out and Balance is the stock at the
+// location after the change.
+// ReferenceID is the checkout, refund or other record that caused it, and
+// ActorID the staff member who made it.
+type StockMovement struct {
+	ID          string
+	ProductID   string
+	LocationID  string
+	Delta       int
+	Balance     int
+	Reason      MovementReason
+	ActorID     *string
+	ReferenceID *string
+	CreatedAt   time.Time
 }
@@ -2,6 +2,8 @@ package product
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -14,6 +16,9 @@ type Repository interface {
 	Put(ctx context.Context, product *Product) error
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, req ListProductPayload) ([]Product, error)
+	ListLowStock(ctx context.Context, req ListLowStockPayload) ([]Product, error)
+	AdjustStock(ctx context.Context, movement *StockMovement) error
+	ListStockMovements(ctx context.Context, req ListStockMovementsPayload) ([]*StockMovement, error)
 }
 
 type dbRepository struct {
@@ -24,18 +29,39 @@ func NewRepository(db *db.DB) Repository {
 	return &dbRepository{db: db}
 }
 
+// Create stores product together with its stock at each of its locations.
 func (d *dbRepository) Create(ctx context.Context, product *Product) (*Product, error) {
-	createUserQuery := `
-		INSERT INTO products (
-			id, name, sku, category, image_url, notes, price, stock, location, is_available
-		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
-		) RETURNING created_at;
-	`
-	row := d.db.DB().QueryRowContext(ctx, createUserQuery,
-		product.ID, product.Name, product.SKU, product.Category, product.ImageURL, product.Notes, product.Price, product.Stock,
-		product.Location, product.IsAvailable)
-	err := row.Scan(&product.CreatedAt)
+	err := d.db.StartTx(ctx, func(tx *sql.Tx) error {
+		createUserQuery := `
+			INSERT INTO products (
+				id, name, sku, category, image_url, notes, price, stock, reorder_point, is_available
+			) VALUES (
+				$1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+			) RETURNING created_at;
+		`
+		row := tx.QueryRowContext(ctx, createUserQuery,
+			product.ID, product.Name, product.SKU, product.Category, product.ImageURL, product.Notes, product.Price, product.Stock,
+			product.ReorderPoint, product.IsAvailable)
+		err := row.Scan(&product.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		for _, locationStock := range product.Locations {
+			q := `
+				INSERT INTO product_stocks (
+					product_id, location_id, stock
+				) VALUES (
+					$1, $2, $3
+				);
+			`
+			_, err := tx.ExecContext(ctx, q, product.ID, locationStock.LocationID, locationStock.Stock)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -47,21 +73,11 @@ func (d *dbRepository) GetByMultipleID(ctx context.Context, ids []string) ([]*Pr
 		return make([]*Product, 0), nil
 	}
 	q := `
-		SELECT *
+		SELECT id, name, sku, category, image_url, notes, price, stock, damaged_stock, reorder_point, ` + locationStocksColumn + `, is_available, created_at
 		FROM products
-		WHERE id IN(
+		WHERE id = ANY($1);
 	`
-
-	for i, v := range ids {
-		if i > 0 {
-			q += ","
-		}
-		q += fmt.Sprintf("'%s'", v)
-
-	}
-
-	q += ");"
-	rows, err := d.db.DB().QueryContext(ctx, q)
+	rows, err := d.db.DB().QueryContext(ctx, q, ids)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +85,7 @@ func (d *dbRepository) GetByMultipleID(ctx context.Context, ids []string) ([]*Pr
 	res := make([]*Product, 0)
 	for rows.Next() {
 		p := &Product{}
-		err := rows.Scan(&p.ID, &p.Name, &p.SKU, &p.Category, &p.ImageURL, &p.Notes, &p.Price, &p.Stock, &p.Location, &p.IsAvailable, &p.CreatedAt)
+		err := rows.Scan(&p.ID, &p.Name, &p.SKU, &p.Category, &p.ImageURL, &p.Notes, &p.Price, &p.Stock, &p.DamagedStock, &p.ReorderPoint, &p.Locations, &p.IsAvailable, &p.CreatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -78,13 +94,17 @@ func (d *dbRepository) GetByMultipleID(ctx context.Context, ids []string) ([]*Pr
 	return res, nil
 }
 
+// Put updates product. Moving the reorder point below the current stock
+// re-arms the low stock alert.
 func (d *dbRepository) Put(ctx context.Context, product *Product) error {
 	q := `
         UPDATE products
-        SET name = $1, sku = $2, category = $3, image_url = $4, notes = $5, price = $6, stock = $7, location = $8, is_available = $9
-        WHERE id = $10;
+        SET name = $1, sku = $2, category = $3, image_url = $4, notes = $5, price = $6, is_available = $7,
+            reorder_point = $8, low_stock_alerted_at = ` + lowStockAlertedAt("stock", "$8") + `
+        WHERE id = $9;
     `
-	row, err := d.db.DB().ExecContext(ctx, q, product.Name, product.SKU, product.Category, product.ImageURL, product.Notes, product.Price, product.Stock, product.Location, product.IsAvailable, product.ID)
+	row, err := d.db.DB().ExecContext(ctx, q, product.Name, product.SKU, product.Category, product.ImageURL, product.Notes, product.Price, product.IsAvailable,
+		product.ReorderPoint, product.ID)
 	if err != nil {
 		return err
 	}
@@ -98,6 +118,119 @@ func (d *dbRepository) Put(ctx context.Context, product *Product) error {
 	return nil
 }
 
+// ListLowStock lists products with stock at or below their reorder point,
+// furthest below it first.
+func (d *dbRepository) ListLowStock(ctx context.Context, req ListLowStockPayload) ([]Product, error) {
+	q := `
+		SELECT id, name, sku, category, image_url, stock, damaged_stock, reorder_point, ` + onOrderColumn + `, ` + locationStocksColumn + `, notes, price, is_available, created_at
+		FROM products
+		WHERE reorder_point IS NOT NULL AND stock <= reorder_point
+	`
+	params := make([]interface{}, 0)
+	if req.Category != "" {
+		params = append(params, req.Category)
+		q += fmt.Sprintf("AND category = $%d ", len(params))
+	}
+	q += fmt.Sprintf("ORDER BY stock - reorder_point, id OFFSET $%d LIMIT $%d;", len(params)+1, len(params)+2)
+	params = append(params, req.Offset, req.Limit)
+
+	rows, err := d.db.DB().QueryContext(ctx, q, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]Product, 0)
+	for rows.Next() {
+		product := Product{}
+		err = rows.Scan(&product.ID, &product.Name, &product.SKU, &product.Category, &product.ImageURL, &product.Stock, &product.DamagedStock, &product.ReorderPoint, &product.OnOrder,
+			&product.Locations, &product.Notes, &product.Price, &product.IsAvailable, &product.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, product)
+	}
+	return res, rows.Err()
+}
+
+// AdjustStock implements Repository.
+func (d *dbRepository) AdjustStock(ctx context.Context, movement *StockMovement) error {
+	return d.db.StartTx(ctx, func(tx *sql.Tx) error {
+		return MoveStock(ctx, tx, movement)
+	})
+}
+
+// MoveStock moves the stock of movement's product at its location by its
+// Delta within tx, and records movement with the location's new balance.
+// The product's total stock moves with it. ErrProductNotFound is returned if
+// the product does not exist and ErrStockNotEnough if the move would take
+// the stock at the location below zero.
+func MoveStock(ctx context.Context, tx *sql.Tx, movement *StockMovement) error {
+	q := `
+		UPDATE products
+		SET stock = stock + $1, low_stock_alerted_at = ` + lowStockAlertedAt("stock + $1", "reorder_point") + `
+		WHERE id = $2;
+	`
+	row, err := tx.ExecContext(ctx, q, movement.Delta, movement.ProductID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := row.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrProductNotFound
+	}
+
+	q = `
+		INSERT INTO product_stocks (
+			product_id, location_id, stock
+		) VALUES (
+			$1, $2, $3
+		)
+		ON CONFLICT (product_id, location_id) DO UPDATE
+		SET stock = product_stocks.stock + EXCLUDED.stock
+		RETURNING stock;
+	`
+	if movement.Delta < 0 {
+		q = `
+			UPDATE product_stocks
+			SET stock = stock + $3
+			WHERE product_id = $1 AND location_id = $2 AND stock + $3 >= 0
+			RETURNING stock;
+		`
+	}
+	err = tx.QueryRowContext(ctx, q, movement.ProductID, movement.LocationID, movement.Delta).Scan(&movement.Balance)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrStockNotEnough
+	}
+	if err != nil {
+		return err
+	}
+	return insertStockMovement(ctx, tx, movement)
+}
+
+// lowStockAlertedAt returns the SQL expression for low_stock_alerted_at
+// after stock is set to the stock expression and reorder_point to the
+// reorderPoint expression. The alert stays marked as sent while stock is at
+// or below the reorder point and is cleared once stock is replenished, so
+// that it fires again the next time stock runs low.
+func lowStockAlertedAt(stock, reorderPoint string) string {
+	return fmt.Sprintf("CASE WHEN %[2]s IS NULL OR %[1]s > %[2]s THEN NULL ELSE low_stock_alerted_at END", stock, reorderPoint)
+}
+
+func insertStockMovement(ctx context.Context, tx *sql.Tx, movement *StockMovement) error {
+	q := `
+		INSERT INTO stock_movements (
+			id, product_id, location_id, delta, balance, reason, actor_id, reference_id
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8
+		) RETURNING created_at;
+	`
+	return tx.QueryRowContext(ctx, q, movement.ID, movement.ProductID, movement.LocationID, movement.Delta, movement.Balance, movement.Reason,
+		movement.ActorID, movement.ReferenceID).Scan(&movement.CreatedAt)
+}
+
 func (d *dbRepository) Delete(ctx context.Context, id string) error {
 	q := `
         DELETE FROM products
@@ -119,7 +252,7 @@ func (d *dbRepository) Delete(ctx context.Context, id string) error {
 
 func (d *dbRepository) List(ctx context.Context, req ListProductPayload) ([]Product, error) {
 	q := `
-		SELECT id, name, sku, category, image_url, stock, notes, price, location, is_available, created_at
+		SELECT id, name, sku, category, image_url, stock, damaged_stock, reorder_point, ` + onOrderColumn + `, ` + locationStocksColumn + `, notes, price, is_available, created_at
 		FROM products
 	`
 	paramNo := 1
@@ -154,12 +287,22 @@ func (d *dbRepository) List(ctx context.Context, req ListProductPayload) ([]Prod
 		params = append(params, req.SKU)
 	}
 
+	stock := "stock"
+	if req.LocationID != "" {
+		q += whereOrAnd(paramNo)
+		q += fmt.Sprintf("EXISTS (SELECT 1 FROM product_stocks ps WHERE ps.product_id = products.id AND ps.location_id = $%d) ", paramNo)
+		// in stock at the location rather than anywhere
+		stock = fmt.Sprintf("(SELECT ps.stock FROM product_stocks ps WHERE ps.product_id = products.id AND ps.location_id = $%d)", paramNo)
+		paramNo += 1
+		params = append(params, req.LocationID)
+	}
+
 	if v, err := strconv.ParseBool(req.InStock); err == nil {
 		q += whereOrAnd(paramNo)
 		if v {
-			q += "stock > 0 "
+			q += stock + " > 0 "
 		} else {
-			q += "stock = 0 "
+			q += stock + " = 0 "
 		}
 	}
 
@@ -200,8 +343,8 @@ func (d *dbRepository) List(ctx context.Context, req ListProductPayload) ([]Prod
 	res := make([]Product, 0)
 	for rows.Next() {
 		product := Product{}
-		err = rows.Scan(&product.ID, &product.Name, &product.SKU, &product.Category, &product.ImageURL, &product.Stock,
-			&product.Notes, &product.Price, &product.Location, &product.IsAvailable, &product.CreatedAt)
+		err = rows.Scan(&product.ID, &product.Name, &product.SKU, &product.Category, &product.ImageURL, &product.Stock, &product.DamagedStock, &product.ReorderPoint, &product.OnOrder,
+			&product.Locations, &product.Notes, &product.Price, &product.IsAvailable, &product.CreatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -210,6 +353,61 @@ func (d *dbRepository) List(ctx context.Context, req ListProductPayload) ([]Prod
 	return res, nil
 }
 
+// ListStockMovements lists the stock movements of a product, newest first.
+func (d *dbRepository) ListStockMovements(ctx context.Context, req ListStockMovementsPayload) ([]*StockMovement, error) {
+	q := `
+		SELECT id, product_id, location_id, delta, balance, reason, actor_id, reference_id, created_at
+		FROM stock_movements
+		WHERE product_id = $1
+	`
+	params := []interface{}{req.ProductID}
+	if req.LocationID != "" {
+		params = append(params, req.LocationID)
+		q += fmt.Sprintf("AND location_id = $%d ", len(params))
+	}
+	if req.Reason != "" {
+		params = append(params, req.Reason)
+		q += fmt.Sprintf("AND reason = $%d ", len(params))
+	}
+	q += fmt.Sprintf("ORDER BY created_at DESC, id OFFSET $%d LIMIT $%d;", len(params)+1, len(params)+2)
+	params = append(params, req.Offset, req.Limit)
+
+	rows, err := d.db.DB().QueryContext(ctx, q, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*StockMovement, 0)
+	for rows.Next() {
+		m := &StockMovement{}
+		err := rows.Scan(&m.ID, &m.ProductID, &m.LocationID, &m.Delta, &m.Balance, &m.Reason, &m.ActorID, &m.ReferenceID, &m.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, m)
+	}
+	return res, rows.Err()
+}
+
+// locationStocksColumn selects the stock of a product at each location as
+// LocationStocks.
+const locationStocksColumn = `
+	COALESCE((
+		SELECT jsonb_agg(jsonb_build_object('locationId', ps.location_id, 'stock', ps.stock) ORDER BY ps.location_id)
+		FROM product_stocks ps
+		WHERE ps.product_id = products.id
+	), '[]')`
+
+// onOrderColumn selects the quantity of a product still outstanding on open
+// purchase orders.
+const onOrderColumn = `
+	COALESCE((
+		SELECT SUM(l.quantity - l.received_quantity)
+		FROM purchase_order_lines l
+		JOIN purchase_orders po ON po.id = l.purchase_order_id
+		WHERE po.status = 'Open' AND l.product_id = products.id
+	), 0)`
+
 func whereOrAnd(paramNo int) string {
 	if paramNo == 1 {
 		return "WHERE "
@@ -13,15 +13,22 @@ var imgUrlValidationRule = validation.NewStringRule(func(s string) bool {
 }, "image url is not valid")
 
 type CreateProductPayload struct {
-	Name        string          `json:"name"`
-	SKU         string          `json:"sku"`
-	Category    ProductCategory `json:"category"`
-	ImageURL    string          `json:"imageURL"`
-	Notes       string          `json:"notes"`
-	Price       int64           `json:"price"`
-	Stock       *int            `json:"stock"`
-	Location    string          `json:"location"`
-	IsAvailable *bool           `json:"isAvailable"`
+	Name         string          `json:"name"`
+	SKU          string          `json:"sku"`
+	Category     ProductCategory `json:"category"`
+	ImageURL     string          `json:"imageURL"`
+	Notes        string          `json:"notes"`
+	Price        int64           `json:"price"`
+	Stock        *int            `json:"stock"`
+	ReorderPoint *int            `json:"reorderPoint"`
+	LocationID   string          `json:"locationId"`
+	IsAvailable  *bool           `json:"isAvailable"`
+
+	// Location is the name of the location stock is kept at, as sent by
+	// clients from before locations had IDs.
+	//
+	// Deprecated: use LocationID.
+	Location string `json:"location"`
 }
 
 func (p CreateProductPayload) Validate() error {
@@ -36,21 +43,28 @@ func (p CreateProductPayload) Validate() error {
 		validation.Field(&p.Notes, validation.Required, validation.Length(1, 200)),
 		validation.Field(&p.Price, validation.Required, validation.Min(1)),
 		validation.Field(&p.Stock, validation.NotNil, validation.Min(0), validation.Max(100000)),
-		validation.Field(&p.Location, validation.Required, validation.Length(1, 200)),
+		validation.Field(&p.ReorderPoint, validation.Min(0), validation.Max(100000)),
+		validation.Field(&p.Location, validation.Length(1, 200)),
 	)
 }
 
 type EditProductPayload struct {
-	ID          string          `json:"-"`
-	Name        string          `json:"name"`
-	SKU         string          `json:"sku"`
-	Category    ProductCategory `json:"category"`
-	ImageURL    string          `json:"imageURL"`
-	Notes       string          `json:"notes"`
-	Price       int64           `json:"price"`
-	Stock       int             `json:"stock"`
-	Location    string          `json:"location"`
-	IsAvailable *bool           `json:"isAvailable"`
+	ID           string          `json:"-"`
+	Name         string          `json:"name"`
+	SKU          string          `json:"sku"`
+	Category     ProductCategory `json:"category"`
+	ImageURL     string          `json:"imageURL"`
+	Notes        string          `json:"notes"`
+	Price        int64           `json:"price"`
+	ReorderPoint *int            `json:"reorderPoint"`
+	IsAvailable  *bool           `json:"isAvailable"`
+
+	// Location is still accepted from clients from before locations had IDs
+	// but is ignored, as stock is moved between locations with stock
+	// adjustments.
+	//
+	// Deprecated: do not send.
+	Location string `json:"location"`
 }
 
 func (p EditProductPayload) Validate() error {
@@ -65,8 +79,29 @@ func (p EditProductPayload) Validate() error {
 		validation.Field(&p.ImageURL, validation.Required, imgUrlValidationRule),
 		validation.Field(&p.Notes, validation.Required, validation.Length(1, 200)),
 		validation.Field(&p.Price, validation.Required, validation.Min(1)),
-		validation.Field(&p.Stock, validation.Required, validation.Min(1), validation.Max(100000)),
-		validation.Field(&p.Location, validation.Required, validation.Length(1, 200)),
+		validation.Field(&p.ReorderPoint, validation.Min(0), validation.Max(100000)),
+	)
+}
+
+// StockAdjustmentPayload moves a product's stock at a location by Delta.
+// Sales, refunds and voids move stock through checkout, so only the manual
+// reasons are accepted here.
+type StockAdjustmentPayload struct {
+	ProductID  string         `json:"-"`
+	StaffID    string         `json:"-"`
+	LocationID string         `json:"locationId"`
+	Delta      int            `json:"delta"`
+	Reason     MovementReason `json:"reason"`
+}
+
+func (p StockAdjustmentPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ProductID, validation.Required),
+		validation.Field(&p.Delta, validation.Required, validation.Min(-100000), validation.Max(100000),
+			// receiving only adds stock and a write-off only takes it out
+			validation.When(p.Reason == MovementReceiving, validation.Min(1)),
+			validation.When(p.Reason == MovementWriteOff, validation.Max(-1))),
+		validation.Field(&p.Reason, validation.Required, validation.In(MovementAdjustment, MovementReceiving, MovementWriteOff)),
 	)
 }
 
@@ -90,5 +125,37 @@ type ListProductPayload struct {
 	SKU         string `schema:"sku" binding:"omitempty"`
 	Price       string `schema:"price" binding:"omitempty"`
 	InStock     string `schema:"inStock" binding:"omitempty"`
+	LocationID  string `schema:"locationId" binding:"omitempty"`
 	CreatedAt   string `schema:"createdAt" binding:"omitempty"`
 }
+
+type ListStockMovementsPayload struct {
+	ProductID  string         `schema:"-"`
+	LocationID string         `schema:"locationId" binding:"omitempty"`
+	Reason     MovementReason `schema:"reason" binding:"omitempty"`
+	Limit      int            `schema:"limit" binding:"omitempty"`
+	Offset     int            `schema:"offset" binding:"omitempty"`
+}
+
+func (p ListStockMovementsPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ProductID, validation.Required),
+		validation.Field(&p.Reason, validation.In(MovementReasons...)),
+		validation.Field(&p.Limit, validation.Min(0)),
+		validation.Field(&p.Offset, validation.Min(0)),
+	)
+}
+
+type ListLowStockPayload struct {
+	Category ProductCategory `schema:"category" binding:"omitempty"`
+	Limit    int             `schema:"limit" binding:"omitempty"`
+	Offset   int             `schema:"offset" binding:"omitempty"`
+}
+
+func (p ListLowStockPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Category, validation.In(ProductCategories...)),
+		validation.Field(&p.Limit, validation.Min(0)),
+		validation.Field(&p.Offset, validation.Min(0)),
+	)
+}
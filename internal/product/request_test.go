@@ -0,0 +1,45 @@
+package product
+
+import "testing"
+
+func TestListLowStockPayloadValidateCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		category ProductCategory
+		wantErr  bool
+	}{
+		{name: "no category", category: ""},
+		{name: "known category", category: CategoryClothing},
+		{name: "unknown category", category: "Groceries", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ListLowStockPayload{Category: tt.category}
+			err := p.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestListStockMovementsPayloadValidateReason(t *testing.T) {
+	tests := []struct {
+		name    string
+		reason  MovementReason
+		wantErr bool
+	}{
+		{name: "no reason", reason: ""},
+		{name: "known reason", reason: MovementSale},
+		{name: "unknown reason", reason: "Theft", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ListStockMovementsPayload{ProductID: "p1", Reason: tt.reason}
+			err := p.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
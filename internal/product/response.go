@@ -6,3 +6,15 @@ type ProductResponse struct {
 	ID        string    `json:"id"`
 	CreatedAt time.Time `json:"createdAt"`
 }
+
+type StockMovementResponse struct {
+	ID          string         `json:"id"`
+	ProductID   string         `json:"productId"`
+	LocationID  string         `json:"locationId"`
+	Delta       int            `json:"delta"`
+	Balance     int            `json:"balance"`
+	Reason      MovementReason `json:"reason"`
+	ActorID     *string        `json:"actorId"`
+	ReferenceID *string        `json:"referenceId"`
+	CreatedAt   time.Time      `json:"createdAt"`
+}
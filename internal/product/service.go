@@ -2,8 +2,12 @@ package product
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/citadel-corp/eniqilo-store/internal/common/id"
+	"github.com/citadel-corp/eniqilo-store/internal/location"
 )
 
 type Service interface {
@@ -12,31 +16,49 @@ type Service interface {
 	Delete(ctx context.Context, req DeleteProductPayload) error
 	List(ctx context.Context, req ListProductPayload) ([]Product, error)
 	ListForCustomers(ctx context.Context, req ListProductPayload) ([]Product, error)
+	ListLowStock(ctx context.Context, req ListLowStockPayload) ([]Product, error)
+	AdjustStock(ctx context.Context, req StockAdjustmentPayload) (*StockMovementResponse, error)
+	ListStockMovements(ctx context.Context, req ListStockMovementsPayload) ([]*StockMovementResponse, error)
 }
 
 type productService struct {
-	repository Repository
+	repository         Repository
+	locationRepository location.Repository
 }
 
-func NewService(repository Repository) Service {
-	return &productService{repository: repository}
+func NewService(repository Repository, locationRepository location.Repository) Service {
+	return &productService{
+		repository:         repository,
+		locationRepository: locationRepository,
+	}
 }
 
 func (s *productService) Create(ctx context.Context, req CreateProductPayload) (*ProductResponse, error) {
+	locationID, err := s.locationID(ctx, req.LocationID)
+	if err != nil {
+		return nil, err
+	}
+	if req.LocationID == "" && req.Location != "" {
+		locationID, err = s.locationIDByName(ctx, req.Location)
+		if err != nil {
+			return nil, err
+		}
+	}
 	product := &Product{
-		ID:          id.GenerateStringID(16),
-		Name:        req.Name,
-		SKU:         req.SKU,
-		Category:    req.Category,
-		ImageURL:    req.ImageURL,
-		Notes:       req.Notes,
-		Price:       req.Price,
-		Stock:       *req.Stock,
-		Location:    req.Location,
-		IsAvailable: *req.IsAvailable,
-	}
-
-	product, err := s.repository.Create(ctx, product)
+		ID:           id.GenerateStringID(16),
+		Name:         req.Name,
+		SKU:          req.SKU,
+		Category:     req.Category,
+		ImageURL:     req.ImageURL,
+		Notes:        req.Notes,
+		Price:        req.Price,
+		Stock:        *req.Stock,
+		ReorderPoint: req.ReorderPoint,
+		Locations:    LocationStocks{{LocationID: locationID, Stock: *req.Stock}},
+		IsAvailable:  *req.IsAvailable,
+	}
+
+	product, err = s.repository.Create(ctx, product)
 	if err != nil {
 		return nil, err
 	}
@@ -49,16 +71,15 @@ func (s *productService) Create(ctx context.Context, req CreateProductPayload) (
 
 func (s *productService) Edit(ctx context.Context, req EditProductPayload) error {
 	product := &Product{
-		ID:          req.ID,
-		Name:        req.Name,
-		SKU:         req.SKU,
-		Category:    req.Category,
-		ImageURL:    req.ImageURL,
-		Notes:       req.Notes,
-		Price:       req.Price,
-		Stock:       req.Stock,
-		Location:    req.Location,
-		IsAvailable: *req.IsAvailable,
+		ID:           req.ID,
+		Name:         req.Name,
+		SKU:          req.SKU,
+		Category:     req.Category,
+		ImageURL:     req.ImageURL,
+		Notes:        req.Notes,
+		Price:        req.Price,
+		ReorderPoint: req.ReorderPoint,
+		IsAvailable:  *req.IsAvailable,
 	}
 	err := s.repository.Put(ctx, product)
 	if err != nil {
@@ -103,3 +124,109 @@ func (s *productService) ListForCustomers(ctx context.Context, req ListProductPa
 
 	return products, nil
 }
+
+func (s *productService) ListLowStock(ctx context.Context, req ListLowStockPayload) ([]Product, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+
+	products, err := s.repository.ListLowStock(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (s *productService) AdjustStock(ctx context.Context, req StockAdjustmentPayload) (*StockMovementResponse, error) {
+	locationID, err := s.locationID(ctx, req.LocationID)
+	if err != nil {
+		return nil, err
+	}
+	movement := &StockMovement{
+		ID:         id.GenerateStringID(16),
+		ProductID:  req.ProductID,
+		LocationID: locationID,
+		Delta:      req.Delta,
+		Reason:     req.Reason,
+	}
+	if req.StaffID != "" {
+		movement.ActorID = &req.StaffID
+	}
+	err = s.repository.AdjustStock(ctx, movement)
+	if err != nil {
+		return nil, err
+	}
+
+	return toStockMovementResponse(movement), nil
+}
+
+func (s *productService) ListStockMovements(ctx context.Context, req ListStockMovementsPayload) ([]*StockMovementResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+
+	movements, err := s.repository.ListStockMovements(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*StockMovementResponse, len(movements))
+	for i, m := range movements {
+		res[i] = toStockMovementResponse(m)
+	}
+	return res, nil
+}
+
+// locationID returns the location stock is kept at, which is the default
+// location if none is given.
+func (s *productService) locationID(ctx context.Context, id string) (string, error) {
+	if id == "" {
+		return location.DefaultID, nil
+	}
+	_, err := s.locationRepository.GetByID(ctx, id)
+	if errors.Is(err, location.ErrLocationNotFound) {
+		return "", ErrLocationNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// locationIDByName returns the location named name, the way product locations
+// were carried over to the locations table. Names that are not a location
+// fall back to the default location.
+func (s *productService) locationIDByName(ctx context.Context, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if runes := []rune(name); len(runes) > 50 {
+		name = string(runes[:50])
+	}
+	l, err := s.locationRepository.GetByName(ctx, name)
+	if errors.Is(err, location.ErrLocationNotFound) {
+		return location.DefaultID, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return l.ID, nil
+}
+
+func toStockMovementResponse(m *StockMovement) *StockMovementResponse {
+	return &StockMovementResponse{
+		ID:          m.ID,
+		ProductID:   m.ProductID,
+		LocationID:  m.LocationID,
+		Delta:       m.Delta,
+		Balance:     m.Balance,
+		Reason:      m.Reason,
+		ActorID:     m.ActorID,
+		ReferenceID: m.ReferenceID,
+		CreatedAt:   m.CreatedAt,
+	}
+}
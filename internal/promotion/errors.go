@@ -0,0 +1,9 @@
+package promotion
+
+import "errors"
+
+var (
+	ErrValidationFailed  = errors.New("validation failed")
+	ErrPromotionNotFound = errors.New("promotion not found")
+	ErrProductNotFound   = errors.New("product not found")
+)
@@ -0,0 +1,85 @@
+package promotion
+
+import (
+	"time"
+
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+type PromotionType string
+
+var (
+	TypePercentage  PromotionType = "Percentage"
+	TypeFixedAmount PromotionType = "FixedAmount"
+	TypeBuyXGetY    PromotionType = "BuyXGetY"
+)
+
+var PromotionTypes = []interface{}{TypePercentage, TypeFixedAmount, TypeBuyXGetY}
+
+// Promotion is a discount rule. A promotion with a ProductID applies to that
+// product only, one with a Category applies to every product in it, and one
+// with neither applies store-wide.
+type Promotion struct {
+	ID          string
+	Name        string
+	Type        PromotionType
+	ProductID   *string
+	Category    *product.ProductCategory
+	Percentage  int
+	Amount      int64
+	BuyQuantity int
+	GetQuantity int
+	StartsAt    time.Time
+	EndsAt      time.Time
+	CreatedAt   time.Time
+}
+
+// AppliesTo reports whether p covers a product with the given id and category.
+func (p *Promotion) AppliesTo(productID string, category product.ProductCategory) bool {
+	if p.ProductID != nil && *p.ProductID != productID {
+		return false
+	}
+	if p.Category != nil && *p.Category != category {
+		return false
+	}
+	return true
+}
+
+// Discount returns the amount taken off a line of quantity units at price.
+func (p *Promotion) Discount(price int64, quantity int) int64 {
+	total := price * int64(quantity)
+	var discount int64
+	switch p.Type {
+	case TypePercentage:
+		discount = total * int64(p.Percentage) / 100
+	case TypeFixedAmount:
+		discount = p.Amount * int64(quantity)
+	case TypeBuyXGetY:
+		if p.BuyQuantity+p.GetQuantity > 0 {
+			free := quantity / (p.BuyQuantity + p.GetQuantity) * p.GetQuantity
+			discount = price * int64(free)
+		}
+	}
+	if discount > total {
+		return total
+	}
+	return discount
+}
+
+// Best picks the promotion giving the largest discount on a line. Promotions
+// do not stack; ties go to the promotion listed first.
+func Best(promotions []*Promotion, productID string, category product.ProductCategory, price int64, quantity int) (*Promotion, int64) {
+	var best *Promotion
+	var bestDiscount int64
+	for _, p := range promotions {
+		if !p.AppliesTo(productID, category) {
+			continue
+		}
+		discount := p.Discount(price, quantity)
+		if discount > bestDiscount {
+			best = p
+			bestDiscount = discount
+		}
+	}
+	return best, bestDiscount
+}
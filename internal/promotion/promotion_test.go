@@ -0,0 +1,71 @@
+package promotion
+
+import (
+	"testing"
+
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+func TestDiscount(t *testing.T) {
+	tests := []struct {
+		name      string
+		promotion Promotion
+		price     int64
+		quantity  int
+		want      int64
+	}{
+		{name: "percentage rounds down", promotion: Promotion{Type: TypePercentage, Percentage: 15}, price: 333, quantity: 1, want: 49},
+		{name: "percentage of the line", promotion: Promotion{Type: TypePercentage, Percentage: 10}, price: 1000, quantity: 3, want: 300},
+		{name: "fixed amount per unit", promotion: Promotion{Type: TypeFixedAmount, Amount: 150}, price: 1000, quantity: 3, want: 450},
+		{name: "fixed amount capped at the line", promotion: Promotion{Type: TypeFixedAmount, Amount: 1500}, price: 1000, quantity: 2, want: 2000},
+		{name: "buy 2 get 1 under the threshold", promotion: Promotion{Type: TypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1}, price: 1000, quantity: 2, want: 0},
+		{name: "buy 2 get 1", promotion: Promotion{Type: TypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1}, price: 1000, quantity: 3, want: 1000},
+		{name: "buy 2 get 1 twice over with a spare", promotion: Promotion{Type: TypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1}, price: 1000, quantity: 7, want: 2000},
+		{name: "buy 1 get 2", promotion: Promotion{Type: TypeBuyXGetY, BuyQuantity: 1, GetQuantity: 2}, price: 500, quantity: 6, want: 2000},
+		{name: "buy x get y without quantities", promotion: Promotion{Type: TypeBuyXGetY}, price: 1000, quantity: 3, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.promotion.Discount(tt.price, tt.quantity); got != tt.want {
+				t.Errorf("Discount(%d, %d) = %d, want %d", tt.price, tt.quantity, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBest(t *testing.T) {
+	productID := "p1"
+	otherProductID := "p2"
+	footwear := product.CategoryFootwear
+	storeWide := &Promotion{ID: "store", Type: TypePercentage, Percentage: 5}
+	onProduct := &Promotion{ID: "product", ProductID: &productID, Type: TypeFixedAmount, Amount: 100}
+	onOtherProduct := &Promotion{ID: "other", ProductID: &otherProductID, Type: TypePercentage, Percentage: 90}
+	onFootwear := &Promotion{ID: "footwear", Category: &footwear, Type: TypePercentage, Percentage: 50}
+	alsoStoreWide := &Promotion{ID: "store2", Type: TypeFixedAmount, Amount: 50}
+
+	tests := []struct {
+		name       string
+		promotions []*Promotion
+		category   product.ProductCategory
+		wantID     string
+		want       int64
+	}{
+		{name: "nothing applies", promotions: []*Promotion{onOtherProduct, onFootwear}, category: product.CategoryClothing},
+		{name: "largest discount wins", promotions: []*Promotion{storeWide, onProduct, onOtherProduct}, category: product.CategoryClothing, wantID: "product", want: 200},
+		{name: "category promotion", promotions: []*Promotion{storeWide, onProduct, onFootwear}, category: product.CategoryFootwear, wantID: "footwear", want: 1000},
+		{name: "ties go to the first listed", promotions: []*Promotion{storeWide, alsoStoreWide}, category: product.CategoryClothing, wantID: "store", want: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 2 units at 1000
+			best, discount := Best(tt.promotions, productID, tt.category, 1000, 2)
+			var gotID string
+			if best != nil {
+				gotID = best.ID
+			}
+			if gotID != tt.wantID || discount != tt.want {
+				t.Errorf("Best() = %q, %d; want %q, %d", gotID, discount, tt.wantID, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package promotion
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/db"
+)
+
+type Repository interface {
+	Create(ctx context.Context, promotion *Promotion) error
+	List(ctx context.Context, req ListPromotionPayload) ([]*Promotion, error)
+	ListActive(ctx context.Context) ([]*Promotion, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type dbRepository struct {
+	db *db.DB
+}
+
+func NewRepository(db *db.DB) Repository {
+	return &dbRepository{db: db}
+}
+
+// Create implements Repository.
+func (d *dbRepository) Create(ctx context.Context, promotion *Promotion) error {
+	q := `
+		INSERT INTO promotions (
+			id, name, promotion_type, product_id, category, percentage, amount, buy_quantity, get_quantity, starts_at, ends_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+		) RETURNING created_at;
+	`
+	row := d.db.DB().QueryRowContext(ctx, q,
+		promotion.ID, promotion.Name, promotion.Type, promotion.ProductID, promotion.Category, promotion.Percentage,
+		promotion.Amount, promotion.BuyQuantity, promotion.GetQuantity, promotion.StartsAt, promotion.EndsAt)
+	return row.Scan(&promotion.CreatedAt)
+}
+
+// List implements Repository.
+func (d *dbRepository) List(ctx context.Context, req ListPromotionPayload) ([]*Promotion, error) {
+	q := `
+		SELECT id, name, promotion_type, product_id, category, percentage, amount, buy_quantity, get_quantity, starts_at, ends_at, created_at
+		FROM promotions
+	`
+	if v, err := strconv.ParseBool(req.IsActive); err == nil {
+		if v {
+			q += "WHERE starts_at <= current_timestamp AND ends_at > current_timestamp "
+		} else {
+			q += "WHERE starts_at > current_timestamp OR ends_at <= current_timestamp "
+		}
+	}
+	q += fmt.Sprintf("ORDER BY created_at DESC LIMIT %d OFFSET %d;", req.Limit, req.Offset)
+	return d.query(ctx, q)
+}
+
+// ListActive implements Repository.
+func (d *dbRepository) ListActive(ctx context.Context) ([]*Promotion, error) {
+	q := `
+		SELECT id, name, promotion_type, product_id, category, percentage, amount, buy_quantity, get_quantity, starts_at, ends_at, created_at
+		FROM promotions
+		WHERE starts_at <= current_timestamp AND ends_at > current_timestamp
+		ORDER BY created_at ASC;
+	`
+	return d.query(ctx, q)
+}
+
+// Delete implements Repository.
+func (d *dbRepository) Delete(ctx context.Context, id string) error {
+	q := `
+        DELETE FROM promotions
+        WHERE id = $1;
+    `
+	row, err := d.db.DB().ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := row.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrPromotionNotFound
+	}
+	return nil
+}
+
+func (d *dbRepository) query(ctx context.Context, q string, params ...interface{}) ([]*Promotion, error) {
+	rows, err := d.db.DB().QueryContext(ctx, q, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*Promotion, 0)
+	for rows.Next() {
+		p := &Promotion{}
+		err := rows.Scan(&p.ID, &p.Name, &p.Type, &p.ProductID, &p.Category, &p.Percentage, &p.Amount,
+			&p.BuyQuantity, &p.GetQuantity, &p.StartsAt, &p.EndsAt, &p.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, p)
+	}
+	return res, rows.Err()
+}
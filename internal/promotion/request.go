@@ -0,0 +1,52 @@
+package promotion
+
+import (
+	"time"
+
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type CreatePromotionPayload struct {
+	Name        string                   `json:"name"`
+	Type        PromotionType            `json:"type"`
+	ProductID   *string                  `json:"productId"`
+	Category    *product.ProductCategory `json:"category"`
+	Percentage  int                      `json:"percentage"`
+	Amount      int64                    `json:"amount"`
+	BuyQuantity int                      `json:"buyQuantity"`
+	GetQuantity int                      `json:"getQuantity"`
+	StartsAt    time.Time                `json:"startsAt"`
+	EndsAt      time.Time                `json:"endsAt"`
+}
+
+func (p CreatePromotionPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Name, validation.Required, validation.Length(1, 50)),
+		validation.Field(&p.Type, validation.Required, validation.In(PromotionTypes...)),
+		validation.Field(&p.ProductID, validation.NilOrNotEmpty),
+		validation.Field(&p.Category, validation.NilOrNotEmpty, validation.In(product.ProductCategories...)),
+		validation.Field(&p.Percentage, validation.When(p.Type == TypePercentage, validation.Required, validation.Min(1), validation.Max(100))),
+		validation.Field(&p.Amount, validation.When(p.Type == TypeFixedAmount, validation.Required, validation.Min(1))),
+		validation.Field(&p.BuyQuantity, validation.When(p.Type == TypeBuyXGetY, validation.Required, validation.Min(1))),
+		validation.Field(&p.GetQuantity, validation.When(p.Type == TypeBuyXGetY, validation.Required, validation.Min(1))),
+		validation.Field(&p.StartsAt, validation.Required),
+		validation.Field(&p.EndsAt, validation.Required, validation.Min(p.StartsAt)),
+	)
+}
+
+type DeletePromotionPayload struct {
+	ID string
+}
+
+func (p DeletePromotionPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ID, validation.Required),
+	)
+}
+
+type ListPromotionPayload struct {
+	IsActive string `schema:"isActive" binding:"omitempty"`
+	Limit    int    `schema:"limit" binding:"omitempty"`
+	Offset   int    `schema:"offset" binding:"omitempty"`
+}
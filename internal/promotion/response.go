@@ -0,0 +1,22 @@
+package promotion
+
+import (
+	"time"
+
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+type PromotionResponse struct {
+	ID          string                   `json:"id"`
+	Name        string                   `json:"name"`
+	Type        PromotionType            `json:"type"`
+	ProductID   *string                  `json:"productId"`
+	Category    *product.ProductCategory `json:"category"`
+	Percentage  int                      `json:"percentage"`
+	Amount      int64                    `json:"amount"`
+	BuyQuantity int                      `json:"buyQuantity"`
+	GetQuantity int                      `json:"getQuantity"`
+	StartsAt    time.Time                `json:"startsAt"`
+	EndsAt      time.Time                `json:"endsAt"`
+	CreatedAt   time.Time                `json:"createdAt"`
+}
@@ -0,0 +1,102 @@
+package promotion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/id"
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+type Service interface {
+	Create(ctx context.Context, req CreatePromotionPayload) (*PromotionResponse, error)
+	List(ctx context.Context, req ListPromotionPayload) ([]*PromotionResponse, error)
+	Delete(ctx context.Context, req DeletePromotionPayload) error
+}
+
+type promotionService struct {
+	repository        Repository
+	productRepository product.Repository
+}
+
+func NewService(repository Repository, productRepository product.Repository) Service {
+	return &promotionService{
+		repository:        repository,
+		productRepository: productRepository,
+	}
+}
+
+// Create implements Service.
+func (s *promotionService) Create(ctx context.Context, req CreatePromotionPayload) (*PromotionResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if req.ProductID != nil {
+		products, err := s.productRepository.GetByMultipleID(ctx, []string{*req.ProductID})
+		if err != nil {
+			return nil, err
+		}
+		if len(products) == 0 {
+			return nil, ErrProductNotFound
+		}
+	}
+	promotion := &Promotion{
+		ID:          id.GenerateStringID(16),
+		Name:        req.Name,
+		Type:        req.Type,
+		ProductID:   req.ProductID,
+		Category:    req.Category,
+		Percentage:  req.Percentage,
+		Amount:      req.Amount,
+		BuyQuantity: req.BuyQuantity,
+		GetQuantity: req.GetQuantity,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+	}
+	err := s.repository.Create(ctx, promotion)
+	if err != nil {
+		return nil, err
+	}
+	return toPromotionResponse(promotion), nil
+}
+
+// List implements Service.
+func (s *promotionService) List(ctx context.Context, req ListPromotionPayload) ([]*PromotionResponse, error) {
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+	promotions, err := s.repository.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*PromotionResponse, len(promotions))
+	for i, promotion := range promotions {
+		res[i] = toPromotionResponse(promotion)
+	}
+	return res, nil
+}
+
+// Delete implements Service.
+func (s *promotionService) Delete(ctx context.Context, req DeletePromotionPayload) error {
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	return s.repository.Delete(ctx, req.ID)
+}
+
+func toPromotionResponse(promotion *Promotion) *PromotionResponse {
+	return &PromotionResponse{
+		ID:          promotion.ID,
+		Name:        promotion.Name,
+		Type:        promotion.Type,
+		ProductID:   promotion.ProductID,
+		Category:    promotion.Category,
+		Percentage:  promotion.Percentage,
+		Amount:      promotion.Amount,
+		BuyQuantity: promotion.BuyQuantity,
+		GetQuantity: promotion.GetQuantity,
+		StartsAt:    promotion.StartsAt,
+		EndsAt:      promotion.EndsAt,
+		CreatedAt:   promotion.CreatedAt,
+	}
+}
@@ -0,0 +1,14 @@
+package purchase
+
+import "errors"
+
+var (
+	ErrValidationFailed          = errors.New("validation failed")
+	ErrSupplierNotFound          = errors.New("supplier not found")
+	ErrProductNotFound           = errors.New("product not found")
+	ErrPurchaseOrderNotFound     = errors.New("purchase order not found")
+	ErrPurchaseOrderClosed       = errors.New("purchase order is already fully received")
+	ErrProductNotInPurchaseOrder = errors.New("product is not in the purchase order")
+	ErrReceivedExceedsOrdered    = errors.New("received quantity exceeds outstanding quantity")
+	ErrLocationNotFound          = errors.New("location not found")
+)
@@ -0,0 +1,71 @@
+package purchase
+
+import "time"
+
+type Supplier struct {
+	ID          string
+	Name        string
+	ContactName string
+	PhoneNumber string
+	CreatedAt   time.Time
+}
+
+type Status string
+
+var (
+	StatusOpen     Status = "Open"
+	StatusReceived Status = "Received"
+)
+
+var Statuses = []interface{}{StatusOpen, StatusReceived}
+
+// PurchaseOrder is stock ordered from a supplier for delivery to LocationID.
+// It stays open until every line has been received in full, possibly over
+// several deliveries.
+type PurchaseOrder struct {
+	ID         string
+	SupplierID string
+	StaffID    string
+	LocationID string
+	Status     Status
+	Lines      []Line
+	CreatedAt  time.Time
+	ClosedAt   *time.Time
+}
+
+// Total returns the cost of everything ordered.
+func (po *PurchaseOrder) Total() int64 {
+	var total int64
+	for _, line := range po.Lines {
+		total += line.Total()
+	}
+	return total
+}
+
+// FullyReceived reports whether every line has been received in full.
+func (po *PurchaseOrder) FullyReceived() bool {
+	for _, line := range po.Lines {
+		if line.Outstanding() > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Line is a product ordered on a purchase order. UnitCost is what the
+// supplier charges per unit.
+type Line struct {
+	ProductID        string
+	Quantity         int
+	ReceivedQuantity int
+	UnitCost         int64
+}
+
+// Outstanding returns the quantity still to be delivered.
+func (l Line) Outstanding() int {
+	return l.Quantity - l.ReceivedQuantity
+}
+
+func (l Line) Total() int64 {
+	return l.UnitCost * int64(l.Quantity)
+}
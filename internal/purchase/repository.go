@@ -0,0 +1,323 @@
+package purchase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/db"
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+type Repository interface {
+	CreateSupplier(ctx context.Context, supplier *Supplier) error
+	GetSupplier(ctx context.Context, id string) (*Supplier, error)
+	ListSuppliers(ctx context.Context, req ListSuppliersPayload) ([]*Supplier, error)
+	CreatePurchaseOrder(ctx context.Context, po *PurchaseOrder) error
+	GetPurchaseOrder(ctx context.Context, id string) (*PurchaseOrder, error)
+	ListPurchaseOrders(ctx context.Context, req ListPurchaseOrdersPayload) ([]*PurchaseOrder, error)
+	ReceivePurchaseOrder(ctx context.Context, id string, prepare func(po *PurchaseOrder) ([]product.StockMovement, error)) (*PurchaseOrder, error)
+}
+
+type dbRepository struct {
+	db *db.DB
+}
+
+func NewRepository(db *db.DB) Repository {
+	return &dbRepository{db: db}
+}
+
+// CreateSupplier implements Repository.
+func (d *dbRepository) CreateSupplier(ctx context.Context, supplier *Supplier) error {
+	q := `
+		INSERT INTO suppliers (
+			id, name, contact_name, phone_number
+		) VALUES (
+			$1, $2, $3, $4
+		) RETURNING created_at;
+	`
+	return d.db.DB().QueryRowContext(ctx, q, supplier.ID, supplier.Name, supplier.ContactName, supplier.PhoneNumber).Scan(&supplier.CreatedAt)
+}
+
+// GetSupplier implements Repository.
+func (d *dbRepository) GetSupplier(ctx context.Context, id string) (*Supplier, error) {
+	q := `
+		SELECT id, name, contact_name, phone_number, created_at
+		FROM suppliers
+		WHERE id = $1;
+	`
+	s := &Supplier{}
+	err := d.db.DB().QueryRowContext(ctx, q, id).Scan(&s.ID, &s.Name, &s.ContactName, &s.PhoneNumber, &s.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrSupplierNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// ListSuppliers implements Repository.
+func (d *dbRepository) ListSuppliers(ctx context.Context, req ListSuppliersPayload) ([]*Supplier, error) {
+	q := `
+		SELECT id, name, contact_name, phone_number, created_at
+		FROM suppliers
+	`
+	params := make([]interface{}, 0)
+	if req.Name != "" {
+		params = append(params, "%"+strings.ToLower(req.Name)+"%")
+		q += fmt.Sprintf("WHERE LOWER(name) LIKE $%d ", len(params))
+	}
+	q += fmt.Sprintf("ORDER BY name, id OFFSET $%d LIMIT $%d;", len(params)+1, len(params)+2)
+	params = append(params, req.Offset, req.Limit)
+
+	rows, err := d.db.DB().QueryContext(ctx, q, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*Supplier, 0)
+	for rows.Next() {
+		s := &Supplier{}
+		err := rows.Scan(&s.ID, &s.Name, &s.ContactName, &s.PhoneNumber, &s.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
+
+// CreatePurchaseOrder implements Repository.
+func (d *dbRepository) CreatePurchaseOrder(ctx context.Context, po *PurchaseOrder) error {
+	return d.db.StartTx(ctx, func(tx *sql.Tx) error {
+		q := `
+			INSERT INTO purchase_orders (
+				id, supplier_id, staff_id, location_id, status
+			) VALUES (
+				$1, $2, $3, $4, $5
+			) RETURNING created_at;
+		`
+		err := tx.QueryRowContext(ctx, q, po.ID, po.SupplierID, po.StaffID, po.LocationID, po.Status).Scan(&po.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range po.Lines {
+			q = `
+				INSERT INTO purchase_order_lines (
+					purchase_order_id, product_id, quantity, received_quantity, unit_cost
+				) VALUES (
+					$1, $2, $3, $4, $5
+				);
+			`
+			_, err := tx.ExecContext(ctx, q, po.ID, line.ProductID, line.Quantity, line.ReceivedQuantity, line.UnitCost)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetPurchaseOrder implements Repository.
+func (d *dbRepository) GetPurchaseOrder(ctx context.Context, id string) (*PurchaseOrder, error) {
+	q := `
+		SELECT id, supplier_id, staff_id, location_id, status, created_at, closed_at
+		FROM purchase_orders
+		WHERE id = $1;
+	`
+	po, err := scanPurchaseOrder(d.db.DB().QueryRowContext(ctx, q, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPurchaseOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = loadLines(ctx, d.db.DB(), []*PurchaseOrder{po})
+	if err != nil {
+		return nil, err
+	}
+	return po, nil
+}
+
+// ListPurchaseOrders implements Repository.
+func (d *dbRepository) ListPurchaseOrders(ctx context.Context, req ListPurchaseOrdersPayload) ([]*PurchaseOrder, error) {
+	q := `
+		SELECT id, supplier_id, staff_id, location_id, status, created_at, closed_at
+		FROM purchase_orders po
+	`
+	conditions := make([]string, 0)
+	params := make([]interface{}, 0)
+	addCondition := func(condition string, param interface{}) {
+		params = append(params, param)
+		conditions = append(conditions, fmt.Sprintf(condition, len(params)))
+	}
+	if req.SupplierID != "" {
+		addCondition("supplier_id = $%d", req.SupplierID)
+	}
+	if req.Status != "" {
+		addCondition("status = $%d", req.Status)
+	}
+	if req.ProductID != "" {
+		addCondition("EXISTS (SELECT 1 FROM purchase_order_lines l WHERE l.purchase_order_id = po.id AND l.product_id = $%d)", req.ProductID)
+	}
+	if len(conditions) > 0 {
+		q += "WHERE " + strings.Join(conditions, " AND ") + " "
+	}
+	q += fmt.Sprintf("ORDER BY created_at DESC, id OFFSET $%d LIMIT $%d;", len(params)+1, len(params)+2)
+	params = append(params, req.Offset, req.Limit)
+
+	rows, err := d.db.DB().QueryContext(ctx, q, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*PurchaseOrder, 0)
+	for rows.Next() {
+		po, err := scanPurchaseOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, po)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	err = loadLines(ctx, d.db.DB(), res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ReceivePurchaseOrder implements Repository.
+// The purchase order is locked and handed to prepare, which adds the delivery
+// to the received quantities of its lines, updates its status and returns the
+// stock movements for what arrived. All of it is stored in a single
+// transaction, so concurrent deliveries cannot receive more than was ordered.
+func (d *dbRepository) ReceivePurchaseOrder(ctx context.Context, id string, prepare func(po *PurchaseOrder) ([]product.StockMovement, error)) (*PurchaseOrder, error) {
+	var po *PurchaseOrder
+	err := d.db.StartTx(ctx, func(tx *sql.Tx) error {
+		q := `
+			SELECT id, supplier_id, staff_id, location_id, status, created_at, closed_at
+			FROM purchase_orders
+			WHERE id = $1
+			FOR UPDATE;
+		`
+		var err error
+		po, err = scanPurchaseOrder(tx.QueryRowContext(ctx, q, id))
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrPurchaseOrderNotFound
+		}
+		if err != nil {
+			return err
+		}
+		err = loadLines(ctx, tx, []*PurchaseOrder{po})
+		if err != nil {
+			return err
+		}
+
+		movements, err := prepare(po)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range po.Lines {
+			q = `
+				UPDATE purchase_order_lines
+				SET received_quantity = $1
+				WHERE purchase_order_id = $2 AND product_id = $3;
+			`
+			_, err := tx.ExecContext(ctx, q, line.ReceivedQuantity, po.ID, line.ProductID)
+			if err != nil {
+				return err
+			}
+		}
+		q = `
+			UPDATE purchase_orders
+			SET status = $1, closed_at = CASE WHEN $1 = 'Received' THEN current_timestamp END
+			WHERE id = $2
+			RETURNING closed_at;
+		`
+		err = tx.QueryRowContext(ctx, q, po.Status, po.ID).Scan(&po.ClosedAt)
+		if err != nil {
+			return err
+		}
+		return applyStockMovements(ctx, tx, movements)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return po, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanPurchaseOrder(row rowScanner) (*PurchaseOrder, error) {
+	po := &PurchaseOrder{}
+	err := row.Scan(&po.ID, &po.SupplierID, &po.StaffID, &po.LocationID, &po.Status, &po.CreatedAt, &po.ClosedAt)
+	if err != nil {
+		return nil, err
+	}
+	return po, nil
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// loadLines fills in the lines of pos.
+func loadLines(ctx context.Context, q querier, pos []*PurchaseOrder) error {
+	if len(pos) == 0 {
+		return nil
+	}
+	ids := make([]string, len(pos))
+	byID := make(map[string]*PurchaseOrder, len(pos))
+	for i, po := range pos {
+		ids[i] = po.ID
+		byID[po.ID] = po
+		po.Lines = make([]Line, 0)
+	}
+	rows, err := q.QueryContext(ctx, `
+		SELECT purchase_order_id, product_id, quantity, received_quantity, unit_cost
+		FROM purchase_order_lines
+		WHERE purchase_order_id = ANY($1)
+		ORDER BY purchase_order_id, product_id;
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var purchaseOrderID string
+		line := Line{}
+		err := rows.Scan(&purchaseOrderID, &line.ProductID, &line.Quantity, &line.ReceivedQuantity, &line.UnitCost)
+		if err != nil {
+			return err
+		}
+		po := byID[purchaseOrderID]
+		po.Lines = append(po.Lines, line)
+	}
+	return rows.Err()
+}
+
+// applyStockMovements adds the received quantities to the products' stock at
+// the delivery location and records each movement. Stock that arrives for a
+// product that has since been deleted is not recorded.
+func applyStockMovements(ctx context.Context, tx *sql.Tx, movements []product.StockMovement) error {
+	for i := range movements {
+		err := product.MoveStock(ctx, tx, &movements[i])
+		if errors.Is(err, product.ErrProductNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
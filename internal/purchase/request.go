@@ -0,0 +1,104 @@
+package purchase
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type CreateSupplierPayload struct {
+	Name        string `json:"name"`
+	ContactName string `json:"contactName"`
+	PhoneNumber string `json:"phoneNumber"`
+}
+
+func (p CreateSupplierPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Name, validation.Required, validation.Length(1, 50)),
+		validation.Field(&p.ContactName, validation.Length(1, 50)),
+		validation.Field(&p.PhoneNumber, validation.Length(10, 16)),
+	)
+}
+
+type ListSuppliersPayload struct {
+	Name   string `schema:"name" binding:"omitempty"`
+	Limit  int    `schema:"limit" binding:"omitempty"`
+	Offset int    `schema:"offset" binding:"omitempty"`
+}
+
+func (p ListSuppliersPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Limit, validation.Min(0)),
+		validation.Field(&p.Offset, validation.Min(0)),
+	)
+}
+
+type CreatePurchaseOrderPayload struct {
+	SupplierID string        `json:"supplierId"`
+	StaffID    string        `json:"-"`
+	LocationID string        `json:"locationId"`
+	Lines      []LinePayload `json:"lines"`
+}
+
+func (p CreatePurchaseOrderPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.SupplierID, validation.Required),
+		validation.Field(&p.StaffID, validation.Required),
+		validation.Field(&p.Lines, validation.Required),
+	)
+}
+
+type LinePayload struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+	UnitCost  int64  `json:"unitCost"`
+}
+
+func (p LinePayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ProductID, validation.Required),
+		validation.Field(&p.Quantity, validation.Required, validation.Min(1), validation.Max(100000)),
+		validation.Field(&p.UnitCost, validation.Required, validation.Min(1)),
+	)
+}
+
+type ListPurchaseOrdersPayload struct {
+	SupplierID string `schema:"supplierId" binding:"omitempty"`
+	ProductID  string `schema:"productId" binding:"omitempty"`
+	Status     Status `schema:"status" binding:"omitempty"`
+	Limit      int    `schema:"limit" binding:"omitempty"`
+	Offset     int    `schema:"offset" binding:"omitempty"`
+}
+
+func (p ListPurchaseOrdersPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Status, validation.In(Statuses...)),
+		validation.Field(&p.Limit, validation.Min(0)),
+		validation.Field(&p.Offset, validation.Min(0)),
+	)
+}
+
+// ReceivePayload is a delivery against a purchase order. It may cover only
+// part of what was ordered.
+type ReceivePayload struct {
+	PurchaseOrderID string               `json:"-"`
+	StaffID         string               `json:"-"`
+	Lines           []ReceiveLinePayload `json:"lines"`
+}
+
+func (p ReceivePayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.PurchaseOrderID, validation.Required),
+		validation.Field(&p.Lines, validation.Required),
+	)
+}
+
+type ReceiveLinePayload struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+}
+
+func (p ReceiveLinePayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ProductID, validation.Required),
+		validation.Field(&p.Quantity, validation.Required, validation.Min(1), validation.Max(100000)),
+	)
+}
@@ -0,0 +1,32 @@
+package purchase
+
+import "time"
+
+type SupplierResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	ContactName string    `json:"contactName"`
+	PhoneNumber string    `json:"phoneNumber"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type PurchaseOrderResponse struct {
+	ID         string         `json:"id"`
+	SupplierID string         `json:"supplierId"`
+	StaffID    string         `json:"staffId"`
+	LocationID string         `json:"locationId"`
+	Status     Status         `json:"status"`
+	Lines      []LineResponse `json:"lines"`
+	Total      int64          `json:"total"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	ClosedAt   *time.Time     `json:"closedAt"`
+}
+
+type LineResponse struct {
+	ProductID           string `json:"productId"`
+	Quantity            int    `json:"quantity"`
+	ReceivedQuantity    int    `json:"receivedQuantity"`
+	OutstandingQuantity int    `json:"outstandingQuantity"`
+	UnitCost            int64  `json:"unitCost"`
+	Total               int64  `json:"total"`
+}
@@ -0,0 +1,254 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/id"
+	"github.com/citadel-corp/eniqilo-store/internal/location"
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+type Service interface {
+	CreateSupplier(ctx context.Context, req CreateSupplierPayload) (*SupplierResponse, error)
+	ListSuppliers(ctx context.Context, req ListSuppliersPayload) ([]*SupplierResponse, error)
+	CreatePurchaseOrder(ctx context.Context, req CreatePurchaseOrderPayload) (*PurchaseOrderResponse, error)
+	GetPurchaseOrder(ctx context.Context, id string) (*PurchaseOrderResponse, error)
+	ListPurchaseOrders(ctx context.Context, req ListPurchaseOrdersPayload) ([]*PurchaseOrderResponse, error)
+	ReceivePurchaseOrder(ctx context.Context, req ReceivePayload) (*PurchaseOrderResponse, error)
+}
+
+type purchaseService struct {
+	repository         Repository
+	productRepository  product.Repository
+	locationRepository location.Repository
+}
+
+func NewService(repository Repository, productRepository product.Repository, locationRepository location.Repository) Service {
+	return &purchaseService{
+		repository:         repository,
+		productRepository:  productRepository,
+		locationRepository: locationRepository,
+	}
+}
+
+// CreateSupplier implements Service.
+func (s *purchaseService) CreateSupplier(ctx context.Context, req CreateSupplierPayload) (*SupplierResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	supplier := &Supplier{
+		ID:          id.GenerateStringID(16),
+		Name:        req.Name,
+		ContactName: req.ContactName,
+		PhoneNumber: req.PhoneNumber,
+	}
+	err := s.repository.CreateSupplier(ctx, supplier)
+	if err != nil {
+		return nil, err
+	}
+	return toSupplierResponse(supplier), nil
+}
+
+// ListSuppliers implements Service.
+func (s *purchaseService) ListSuppliers(ctx context.Context, req ListSuppliersPayload) ([]*SupplierResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+	suppliers, err := s.repository.ListSuppliers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*SupplierResponse, len(suppliers))
+	for i, supplier := range suppliers {
+		res[i] = toSupplierResponse(supplier)
+	}
+	return res, nil
+}
+
+// CreatePurchaseOrder implements Service.
+func (s *purchaseService) CreatePurchaseOrder(ctx context.Context, req CreatePurchaseOrderPayload) (*PurchaseOrderResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	lines := make([]Line, len(req.Lines))
+	productIDs := make([]string, len(req.Lines))
+	seen := make(map[string]bool, len(req.Lines))
+	for i, line := range req.Lines {
+		if err := line.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+		}
+		if seen[line.ProductID] {
+			return nil, fmt.Errorf("%w: product %s is ordered more than once", ErrValidationFailed, line.ProductID)
+		}
+		seen[line.ProductID] = true
+		productIDs[i] = line.ProductID
+		lines[i] = Line{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitCost:  line.UnitCost,
+		}
+	}
+
+	_, err := s.repository.GetSupplier(ctx, req.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	products, err := s.productRepository.GetByMultipleID(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) != len(productIDs) {
+		return nil, ErrProductNotFound
+	}
+	locationID := location.DefaultID
+	if req.LocationID != "" {
+		_, err := s.locationRepository.GetByID(ctx, req.LocationID)
+		if errors.Is(err, location.ErrLocationNotFound) {
+			return nil, ErrLocationNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		locationID = req.LocationID
+	}
+
+	po := &PurchaseOrder{
+		ID:         id.GenerateStringID(16),
+		SupplierID: req.SupplierID,
+		StaffID:    req.StaffID,
+		LocationID: locationID,
+		Status:     StatusOpen,
+		Lines:      lines,
+	}
+	err = s.repository.CreatePurchaseOrder(ctx, po)
+	if err != nil {
+		return nil, err
+	}
+	return toPurchaseOrderResponse(po), nil
+}
+
+// GetPurchaseOrder implements Service.
+func (s *purchaseService) GetPurchaseOrder(ctx context.Context, id string) (*PurchaseOrderResponse, error) {
+	po, err := s.repository.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toPurchaseOrderResponse(po), nil
+}
+
+// ListPurchaseOrders implements Service.
+func (s *purchaseService) ListPurchaseOrders(ctx context.Context, req ListPurchaseOrdersPayload) ([]*PurchaseOrderResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if req.Limit == 0 {
+		req.Limit = 5
+	}
+	pos, err := s.repository.ListPurchaseOrders(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*PurchaseOrderResponse, len(pos))
+	for i, po := range pos {
+		res[i] = toPurchaseOrderResponse(po)
+	}
+	return res, nil
+}
+
+// ReceivePurchaseOrder implements Service.
+func (s *purchaseService) ReceivePurchaseOrder(ctx context.Context, req ReceivePayload) (*PurchaseOrderResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	for _, line := range req.Lines {
+		if err := line.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+		}
+	}
+
+	po, err := s.repository.ReceivePurchaseOrder(ctx, req.PurchaseOrderID, func(po *PurchaseOrder) ([]product.StockMovement, error) {
+		if po.Status != StatusOpen {
+			return nil, ErrPurchaseOrderClosed
+		}
+		lines := make(map[string]*Line, len(po.Lines))
+		for i := range po.Lines {
+			lines[po.Lines[i].ProductID] = &po.Lines[i]
+		}
+
+		movements := make([]product.StockMovement, len(req.Lines))
+		for i, received := range req.Lines {
+			line, ok := lines[received.ProductID]
+			if !ok {
+				return nil, ErrProductNotInPurchaseOrder
+			}
+			if received.Quantity > line.Outstanding() {
+				return nil, ErrReceivedExceedsOrdered
+			}
+			line.ReceivedQuantity += received.Quantity
+			movements[i] = newReceivingMovement(po.ID, po.LocationID, received.ProductID, received.Quantity, req.StaffID)
+		}
+		if po.FullyReceived() {
+			po.Status = StatusReceived
+		}
+		return movements, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toPurchaseOrderResponse(po), nil
+}
+
+func newReceivingMovement(purchaseOrderID, locationID, productID string, quantity int, staffID string) product.StockMovement {
+	movement := product.StockMovement{
+		ID:          id.GenerateStringID(16),
+		ProductID:   productID,
+		LocationID:  locationID,
+		Delta:       quantity,
+		Reason:      product.MovementReceiving,
+		ReferenceID: &purchaseOrderID,
+	}
+	if staffID != "" {
+		movement.ActorID = &staffID
+	}
+	return movement
+}
+
+func toSupplierResponse(supplier *Supplier) *SupplierResponse {
+	return &SupplierResponse{
+		ID:          supplier.ID,
+		Name:        supplier.Name,
+		ContactName: supplier.ContactName,
+		PhoneNumber: supplier.PhoneNumber,
+		CreatedAt:   supplier.CreatedAt,
+	}
+}
+
+func toPurchaseOrderResponse(po *PurchaseOrder) *PurchaseOrderResponse {
+	lines := make([]LineResponse, len(po.Lines))
+	for i, line := range po.Lines {
+		lines[i] = LineResponse{
+			ProductID:           line.ProductID,
+			Quantity:            line.Quantity,
+			ReceivedQuantity:    line.ReceivedQuantity,
+			OutstandingQuantity: line.Outstanding(),
+			UnitCost:            line.UnitCost,
+			Total:               line.Total(),
+		}
+	}
+	return &PurchaseOrderResponse{
+		ID:         po.ID,
+		SupplierID: po.SupplierID,
+		StaffID:    po.StaffID,
+		LocationID: po.LocationID,
+		Status:     po.Status,
+		Lines:      lines,
+		Total:      po.Total(),
+		CreatedAt:  po.CreatedAt,
+		ClosedAt:   po.ClosedAt,
+	}
+}
@@ -0,0 +1,62 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// wantsCSV reports whether a report should be exported as CSV, either by the
+// format query parameter or by the Accept header.
+func wantsCSV(format, accept string) bool {
+	return format == FormatCSV || strings.Contains(accept, "text/csv")
+}
+
+// writeCSV writes records as a CSV attachment named filename.
+func writeCSV(w http.ResponseWriter, filename string, records [][]string) error {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(records); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func topSellersRecords(sales []*ProductSalesResponse) [][]string {
+	records := [][]string{{"productId", "name", "sku", "category", "unitsSold", "revenue"}}
+	for _, s := range sales {
+		records = append(records, []string{
+			s.ProductID,
+			s.Name,
+			s.SKU,
+			string(s.Category),
+			strconv.Itoa(s.UnitsSold),
+			strconv.FormatInt(s.Revenue, 10),
+		})
+	}
+	return records
+}
+
+func slowMoversRecords(movers []*SlowMoverResponse) [][]string {
+	records := [][]string{{"productId", "name", "sku", "category", "stock", "lastSoldAt"}}
+	for _, m := range movers {
+		lastSoldAt := ""
+		if m.LastSoldAt != nil {
+			lastSoldAt = m.LastSoldAt.Format(time.RFC3339)
+		}
+		records = append(records, []string{
+			m.ProductID,
+			m.Name,
+			m.SKU,
+			string(m.Category),
+			strconv.Itoa(m.Stock),
+			lastSoldAt,
+		})
+	}
+	return records
+}
@@ -0,0 +1,7 @@
+package report
+
+import "errors"
+
+var (
+	ErrValidationFailed = errors.New("validation failed")
+)
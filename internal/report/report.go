@@ -0,0 +1,79 @@
+package report
+
+import (
+	"time"
+
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+type Period string
+
+var (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+)
+
+var Periods = []interface{}{PeriodDay, PeriodWeek, PeriodMonth}
+
+type GroupBy string
+
+var (
+	GroupByCategory GroupBy = "category"
+	GroupByStaff    GroupBy = "staff"
+)
+
+var GroupBys = []interface{}{GroupByCategory, GroupByStaff}
+
+type SortBy string
+
+var (
+	SortByUnits   SortBy = "units"
+	SortByRevenue SortBy = "revenue"
+)
+
+var SortBys = []interface{}{SortByUnits, SortByRevenue}
+
+// FormatCSV requests a report as a CSV download instead of JSON.
+const FormatCSV = "csv"
+
+// SalesSummary aggregates the sales that were not voided in one period,
+// optionally for a single category or staff member. A checkout with lines in
+// several categories counts as a transaction in each of them.
+type SalesSummary struct {
+	PeriodStart  time.Time
+	Group        string
+	Revenue      int64
+	UnitsSold    int
+	Transactions int
+}
+
+// AverageBasket returns the average revenue per transaction.
+func (s *SalesSummary) AverageBasket() int64 {
+	if s.Transactions == 0 {
+		return 0
+	}
+	return s.Revenue / int64(s.Transactions)
+}
+
+// ProductSales is how much of a product sold over a period, excluding voided
+// sales.
+type ProductSales struct {
+	ProductID string
+	Name      string
+	SKU       string
+	Category  product.ProductCategory
+	UnitsSold int
+	Revenue   int64
+}
+
+// SlowMover is a product in stock that has not sold recently. LastSoldAt is
+// nil for a product that never sold.
+type SlowMover struct {
+	ProductID  string
+	Name       string
+	SKU        string
+	Category   product.ProductCategory
+	Stock      int
+	LastSoldAt *time.Time
+}
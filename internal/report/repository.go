@@ -0,0 +1,156 @@
+package report
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/db"
+)
+
+type Repository interface {
+	SalesSummary(ctx context.Context, req SalesReportPayload) ([]*SalesSummary, error)
+	TopSellers(ctx context.Context, req TopSellersPayload) ([]*ProductSales, error)
+	SlowMovers(ctx context.Context, req SlowMoversPayload) ([]*SlowMover, error)
+}
+
+type dbRepository struct {
+	db *db.DB
+}
+
+func NewRepository(db *db.DB) Repository {
+	return &dbRepository{db: db}
+}
+
+// SalesSummary implements Repository.
+// Lines are unnested from product_details so that revenue and units can be
+// split by category; transactions are counted once per group.
+func (d *dbRepository) SalesSummary(ctx context.Context, req SalesReportPayload) ([]*SalesSummary, error) {
+	group := "''"
+	switch req.GroupBy {
+	case GroupByCategory:
+		group = "COALESCE(d.detail->>'Category', '')"
+	case GroupByStaff:
+		group = "COALESCE(ch.staff_id, '')"
+	}
+	q := `
+		SELECT
+			date_trunc($3, ch.created_at) AS period_start,
+			` + group + ` AS grp,
+			COALESCE(SUM((d.detail->>'Total')::bigint), 0),
+			COALESCE(SUM((d.detail->>'Quantity')::int), 0),
+			COUNT(DISTINCT ch.id)
+		FROM checkout_histories ch
+		CROSS JOIN LATERAL jsonb_array_elements(ch.product_details) AS d(detail)
+		WHERE ch.voided_at IS NULL AND ch.created_at >= $1::date AND ch.created_at < $2::date + 1
+		GROUP BY period_start, grp
+		ORDER BY period_start ASC, grp ASC;
+	`
+	rows, err := d.db.DB().QueryContext(ctx, q, req.StartDate, req.EndDate, string(req.Period))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*SalesSummary, 0)
+	for rows.Next() {
+		s := &SalesSummary{}
+		err := rows.Scan(&s.PeriodStart, &s.Group, &s.Revenue, &s.UnitsSold, &s.Transactions)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
+
+// TopSellers implements Repository.
+func (d *dbRepository) TopSellers(ctx context.Context, req TopSellersPayload) ([]*ProductSales, error) {
+	q := `
+		SELECT p.id, p.name, p.sku, p.category, SUM((d.detail->>'Quantity')::int) AS units_sold,
+			COALESCE(SUM((d.detail->>'Total')::bigint), 0) AS revenue
+		FROM checkout_histories ch
+		CROSS JOIN LATERAL jsonb_array_elements(ch.product_details) AS d(detail)
+		JOIN products p ON p.id = d.detail->>'ProductID'
+		WHERE ch.voided_at IS NULL AND ch.created_at >= $1::date AND ch.created_at < $2::date + 1
+	`
+	params := []interface{}{req.StartDate, req.EndDate}
+	if req.Category != "" {
+		params = append(params, req.Category)
+		q += fmt.Sprintf("AND p.category = $%d ", len(params))
+	}
+	q += "GROUP BY p.id "
+	if req.SortBy == SortByRevenue {
+		q += "ORDER BY revenue DESC, units_sold DESC, p.id "
+	} else {
+		q += "ORDER BY units_sold DESC, revenue DESC, p.id "
+	}
+	q += limitOffset(&params, req.Limit, req.Offset)
+
+	rows, err := d.db.DB().QueryContext(ctx, q, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*ProductSales, 0)
+	for rows.Next() {
+		s := &ProductSales{}
+		err := rows.Scan(&s.ProductID, &s.Name, &s.SKU, &s.Category, &s.UnitsSold, &s.Revenue)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
+
+// SlowMovers implements Repository.
+// The last sale of each product is found by containment on product_details,
+// which the GIN index on that column answers.
+func (d *dbRepository) SlowMovers(ctx context.Context, req SlowMoversPayload) ([]*SlowMover, error) {
+	q := `
+		SELECT p.id, p.name, p.sku, p.category, p.stock, last_sale.sold_at
+		FROM products p
+		LEFT JOIN LATERAL (
+			SELECT MAX(ch.created_at) AS sold_at
+			FROM checkout_histories ch
+			WHERE ch.voided_at IS NULL
+				AND ch.product_details @> jsonb_build_array(jsonb_build_object('ProductID', p.id))
+		) last_sale ON true
+		WHERE p.stock > 0
+			AND (last_sale.sold_at IS NULL OR last_sale.sold_at < current_timestamp - make_interval(days => $1))
+	`
+	params := []interface{}{req.Days}
+	if req.Category != "" {
+		params = append(params, req.Category)
+		q += fmt.Sprintf("AND p.category = $%d ", len(params))
+	}
+	q += "ORDER BY last_sale.sold_at ASC NULLS FIRST, p.id "
+	q += limitOffset(&params, req.Limit, req.Offset)
+
+	rows, err := d.db.DB().QueryContext(ctx, q, params...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*SlowMover, 0)
+	for rows.Next() {
+		s := &SlowMover{}
+		err := rows.Scan(&s.ProductID, &s.Name, &s.SKU, &s.Category, &s.Stock, &s.LastSoldAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+	}
+	return res, rows.Err()
+}
+
+// limitOffset returns the LIMIT and OFFSET clause for a report. A zero limit
+// returns every row.
+func limitOffset(params *[]interface{}, limit, offset int) string {
+	clause := ""
+	if limit > 0 {
+		*params = append(*params, limit)
+		clause += fmt.Sprintf("LIMIT $%d ", len(*params))
+	}
+	*params = append(*params, offset)
+	return clause + fmt.Sprintf("OFFSET $%d;", len(*params))
+}
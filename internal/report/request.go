@@ -0,0 +1,63 @@
+package report
+
+import (
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type SalesReportPayload struct {
+	StartDate string  `schema:"startDate" binding:"omitempty"`
+	EndDate   string  `schema:"endDate" binding:"omitempty"`
+	Period    Period  `schema:"period" binding:"omitempty"`
+	GroupBy   GroupBy `schema:"groupBy" binding:"omitempty"`
+}
+
+func (p SalesReportPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.StartDate, validation.Required, validation.Date("2006-01-02")),
+		validation.Field(&p.EndDate, validation.Required, validation.Date("2006-01-02")),
+		validation.Field(&p.Period, validation.In(Periods...)),
+		validation.Field(&p.GroupBy, validation.In(GroupBys...)),
+	)
+}
+
+type TopSellersPayload struct {
+	StartDate string                  `schema:"startDate" binding:"omitempty"`
+	EndDate   string                  `schema:"endDate" binding:"omitempty"`
+	Category  product.ProductCategory `schema:"category" binding:"omitempty"`
+	SortBy    SortBy                  `schema:"sortBy" binding:"omitempty"`
+	Format    string                  `schema:"format" binding:"omitempty"`
+	Limit     int                     `schema:"limit" binding:"omitempty"`
+	Offset    int                     `schema:"offset" binding:"omitempty"`
+}
+
+func (p TopSellersPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.StartDate, validation.Required, validation.Date("2006-01-02")),
+		validation.Field(&p.EndDate, validation.Required, validation.Date("2006-01-02")),
+		validation.Field(&p.Category, validation.In(product.ProductCategories...)),
+		validation.Field(&p.SortBy, validation.In(SortBys...)),
+		validation.Field(&p.Format, validation.In(FormatCSV)),
+		validation.Field(&p.Limit, validation.Min(0)),
+		validation.Field(&p.Offset, validation.Min(0)),
+	)
+}
+
+type SlowMoversPayload struct {
+	// Days without a sale before a product counts as a slow mover.
+	Days     int                     `schema:"days" binding:"omitempty"`
+	Category product.ProductCategory `schema:"category" binding:"omitempty"`
+	Format   string                  `schema:"format" binding:"omitempty"`
+	Limit    int                     `schema:"limit" binding:"omitempty"`
+	Offset   int                     `schema:"offset" binding:"omitempty"`
+}
+
+func (p SlowMoversPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Days, validation.Min(0)),
+		validation.Field(&p.Category, validation.In(product.ProductCategories...)),
+		validation.Field(&p.Format, validation.In(FormatCSV)),
+		validation.Field(&p.Limit, validation.Min(0)),
+		validation.Field(&p.Offset, validation.Min(0)),
+	)
+}
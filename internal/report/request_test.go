@@ -0,0 +1,49 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+func TestTopSellersPayloadValidateCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		category product.ProductCategory
+		wantErr  bool
+	}{
+		{name: "no category", category: ""},
+		{name: "known category", category: product.CategoryFootwear},
+		{name: "unknown category", category: "Groceries", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := TopSellersPayload{StartDate: "2024-01-01", EndDate: "2024-01-31", Category: tt.category}
+			err := p.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSlowMoversPayloadValidateCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		category product.ProductCategory
+		wantErr  bool
+	}{
+		{name: "no category", category: ""},
+		{name: "known category", category: product.CategoryBeverages},
+		{name: "unknown category", category: "Groceries", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := SlowMoversPayload{Category: tt.category}
+			err := p.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
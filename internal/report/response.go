@@ -0,0 +1,34 @@
+package report
+
+import (
+	"time"
+
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+type SalesSummaryResponse struct {
+	PeriodStart   time.Time `json:"periodStart"`
+	Group         string    `json:"group,omitempty"`
+	Revenue       int64     `json:"revenue"`
+	UnitsSold     int       `json:"unitsSold"`
+	Transactions  int       `json:"transactions"`
+	AverageBasket int64     `json:"averageBasket"`
+}
+
+type ProductSalesResponse struct {
+	ProductID string                  `json:"productId"`
+	Name      string                  `json:"name"`
+	SKU       string                  `json:"sku"`
+	Category  product.ProductCategory `json:"category"`
+	UnitsSold int                     `json:"unitsSold"`
+	Revenue   int64                   `json:"revenue"`
+}
+
+type SlowMoverResponse struct {
+	ProductID  string                  `json:"productId"`
+	Name       string                  `json:"name"`
+	SKU        string                  `json:"sku"`
+	Category   product.ProductCategory `json:"category"`
+	Stock      int                     `json:"stock"`
+	LastSoldAt *time.Time              `json:"lastSoldAt"`
+}
@@ -0,0 +1,105 @@
+package report
+
+import (
+	"context"
+	"fmt"
+)
+
+type Service interface {
+	SalesReport(ctx context.Context, req SalesReportPayload) ([]*SalesSummaryResponse, error)
+	TopSellers(ctx context.Context, req TopSellersPayload) ([]*ProductSalesResponse, error)
+	SlowMovers(ctx context.Context, req SlowMoversPayload) ([]*SlowMoverResponse, error)
+}
+
+type reportService struct {
+	repository Repository
+}
+
+func NewService(repository Repository) Service {
+	return &reportService{repository: repository}
+}
+
+// SalesReport implements Service.
+func (s *reportService) SalesReport(ctx context.Context, req SalesReportPayload) ([]*SalesSummaryResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if req.Period == "" {
+		req.Period = PeriodDay
+	}
+	summaries, err := s.repository.SalesSummary(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*SalesSummaryResponse, len(summaries))
+	for i, summary := range summaries {
+		res[i] = &SalesSummaryResponse{
+			PeriodStart:   summary.PeriodStart,
+			Group:         summary.Group,
+			Revenue:       summary.Revenue,
+			UnitsSold:     summary.UnitsSold,
+			Transactions:  summary.Transactions,
+			AverageBasket: summary.AverageBasket(),
+		}
+	}
+	return res, nil
+}
+
+// TopSellers implements Service.
+func (s *reportService) TopSellers(ctx context.Context, req TopSellersPayload) ([]*ProductSalesResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if req.SortBy == "" {
+		req.SortBy = SortByUnits
+	}
+	// a CSV export has every row unless a limit is asked for
+	if req.Limit == 0 && req.Format != FormatCSV {
+		req.Limit = 5
+	}
+	sales, err := s.repository.TopSellers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*ProductSalesResponse, len(sales))
+	for i, sale := range sales {
+		res[i] = &ProductSalesResponse{
+			ProductID: sale.ProductID,
+			Name:      sale.Name,
+			SKU:       sale.SKU,
+			Category:  sale.Category,
+			UnitsSold: sale.UnitsSold,
+			Revenue:   sale.Revenue,
+		}
+	}
+	return res, nil
+}
+
+// SlowMovers implements Service.
+func (s *reportService) SlowMovers(ctx context.Context, req SlowMoversPayload) ([]*SlowMoverResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if req.Days == 0 {
+		req.Days = 60
+	}
+	if req.Limit == 0 && req.Format != FormatCSV {
+		req.Limit = 5
+	}
+	movers, err := s.repository.SlowMovers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*SlowMoverResponse, len(movers))
+	for i, mover := range movers {
+		res[i] = &SlowMoverResponse{
+			ProductID:  mover.ProductID,
+			Name:       mover.Name,
+			SKU:        mover.SKU,
+			Category:   mover.Category,
+			Stock:      mover.Stock,
+			LastSoldAt: mover.LastSoldAt,
+		}
+	}
+	return res, nil
+}
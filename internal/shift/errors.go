@@ -0,0 +1,11 @@
+package shift
+
+import "errors"
+
+var (
+	ErrValidationFailed  = errors.New("validation failed")
+	ErrShiftNotFound     = errors.New("no open shift")
+	ErrShiftAlreadyOpen  = errors.New("staff already has an open shift")
+	ErrNotEnoughInDrawer = errors.New("not enough cash in drawer")
+	ErrLocationNotFound  = errors.New("location not found")
+)
@@ -0,0 +1,201 @@
+package shift
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/db"
+)
+
+type Repository interface {
+	Open(ctx context.Context, shift *Shift) error
+	GetOpenByStaffID(ctx context.Context, staffID string) (*Shift, error)
+	Summarize(ctx context.Context, shift *Shift) (*Summary, error)
+	AddCashMovement(ctx context.Context, staffID string, movement *CashMovement) error
+	Close(ctx context.Context, staffID string, countedCash int) (*Summary, error)
+	VarianceByStaff(ctx context.Context, startDate, endDate string) ([]*StaffVariance, error)
+}
+
+type dbRepository struct {
+	db *db.DB
+}
+
+func NewRepository(db *db.DB) Repository {
+	return &dbRepository{db: db}
+}
+
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Open implements Repository.
+func (d *dbRepository) Open(ctx context.Context, shift *Shift) error {
+	q := `
+		INSERT INTO shifts (
+			id, staff_id, location_id, opening_float
+		) VALUES (
+			$1, $2, $3, $4
+		) RETURNING opened_at;
+	`
+	return d.db.DB().QueryRowContext(ctx, q, shift.ID, shift.StaffID, shift.LocationID, shift.OpeningFloat).Scan(&shift.OpenedAt)
+}
+
+// GetOpenByStaffID implements Repository.
+func (d *dbRepository) GetOpenByStaffID(ctx context.Context, staffID string) (*Shift, error) {
+	return getOpenByStaffID(ctx, d.db.DB(), staffID, false)
+}
+
+// Summarize implements Repository.
+func (d *dbRepository) Summarize(ctx context.Context, shift *Shift) (*Summary, error) {
+	return summarize(ctx, d.db.DB(), shift)
+}
+
+// AddCashMovement implements Repository.
+func (d *dbRepository) AddCashMovement(ctx context.Context, staffID string, movement *CashMovement) error {
+	return d.db.StartTx(ctx, func(tx *sql.Tx) error {
+		shift, err := getOpenByStaffID(ctx, tx, staffID, true)
+		if err != nil {
+			return err
+		}
+		summary, err := summarize(ctx, tx, shift)
+		if err != nil {
+			return err
+		}
+		if movement.Amount > summary.Expected {
+			return ErrNotEnoughInDrawer
+		}
+
+		movement.ShiftID = shift.ID
+		q := `
+			INSERT INTO shift_cash_movements (
+				id, shift_id, movement_type, amount, reason
+			) VALUES (
+				$1, $2, $3, $4, $5
+			) RETURNING created_at;
+		`
+		return tx.QueryRowContext(ctx, q, movement.ID, movement.ShiftID, movement.Type, movement.Amount, movement.Reason).Scan(&movement.CreatedAt)
+	})
+}
+
+// Close implements Repository.
+func (d *dbRepository) Close(ctx context.Context, staffID string, countedCash int) (*Summary, error) {
+	var summary *Summary
+	err := d.db.StartTx(ctx, func(tx *sql.Tx) error {
+		shift, err := getOpenByStaffID(ctx, tx, staffID, true)
+		if err != nil {
+			return err
+		}
+		summary, err = summarize(ctx, tx, shift)
+		if err != nil {
+			return err
+		}
+
+		q := `
+			UPDATE shifts
+			SET expected_cash = $1, counted_cash = $2, closed_at = current_timestamp
+			WHERE id = $3
+			RETURNING closed_at;
+		`
+		shift.ExpectedCash = &summary.Expected
+		shift.CountedCash = &countedCash
+		return tx.QueryRowContext(ctx, q, summary.Expected, countedCash, shift.ID).Scan(&shift.ClosedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// VarianceByStaff implements Repository.
+// Shifts are included by the date they were closed, with both dates inclusive.
+func (d *dbRepository) VarianceByStaff(ctx context.Context, startDate, endDate string) ([]*StaffVariance, error) {
+	q := `
+		SELECT staff_id, COUNT(*), SUM(expected_cash), SUM(counted_cash), SUM(counted_cash - expected_cash)
+		FROM shifts
+		WHERE closed_at >= $1::date AND closed_at < $2::date + 1
+		GROUP BY staff_id
+		ORDER BY staff_id;
+	`
+	rows, err := d.db.DB().QueryContext(ctx, q, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*StaffVariance, 0)
+	for rows.Next() {
+		v := &StaffVariance{}
+		err := rows.Scan(&v.StaffID, &v.Shifts, &v.Expected, &v.Counted, &v.Variance)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+	return res, rows.Err()
+}
+
+func getOpenByStaffID(ctx context.Context, q rowQuerier, staffID string, forUpdate bool) (*Shift, error) {
+	query := `
+		SELECT id, staff_id, location_id, opening_float, expected_cash, counted_cash, opened_at, closed_at
+		FROM shifts
+		WHERE staff_id = $1 AND closed_at IS NULL
+	`
+	if forUpdate {
+		query += "FOR UPDATE"
+	}
+	s := &Shift{}
+	err := q.QueryRowContext(ctx, query, staffID).Scan(&s.ID, &s.StaffID, &s.LocationID, &s.OpeningFloat, &s.ExpectedCash, &s.CountedCash, &s.OpenedAt, &s.ClosedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrShiftNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// summarize works out the cash position of shift from its checkouts, cash
+// refunds and cash movements. Voided checkouts are left out as their cash was
+// handed back.
+func summarize(ctx context.Context, q rowQuerier, shift *Shift) (*Summary, error) {
+	query := `
+		SELECT
+			COALESCE((
+				SELECT SUM(p.amount)
+				FROM checkout_payments p
+				JOIN checkout_histories ch ON ch.id = p.checkout_history_id
+				WHERE ch.shift_id = $1 AND p.method = 'Cash'
+			), 0) - COALESCE((
+				SELECT SUM(change)
+				FROM checkout_histories
+				WHERE shift_id = $1
+			), 0),
+			COALESCE((
+				SELECT SUM(amount)
+				FROM checkout_refunds
+				WHERE shift_id = $1 AND method = 'Cash'
+			), 0),
+			COALESCE((
+				SELECT SUM(p.amount)
+				FROM checkout_payments p
+				JOIN checkout_histories ch ON ch.id = p.checkout_history_id
+				WHERE ch.void_shift_id = $1 AND p.method = 'Cash'
+			), 0) - COALESCE((
+				SELECT SUM(change)
+				FROM checkout_histories
+				WHERE void_shift_id = $1
+			), 0),
+			COALESCE((
+				SELECT SUM(amount)
+				FROM shift_cash_movements
+				WHERE shift_id = $1
+			), 0);
+	`
+	summary := &Summary{Shift: shift}
+	err := q.QueryRowContext(ctx, query, shift.ID).Scan(&summary.CashSales, &summary.CashRefunded, &summary.CashVoided, &summary.CashMovedOut)
+	if err != nil {
+		return nil, err
+	}
+	summary.Expected = summary.expectedCash()
+	return summary, nil
+}
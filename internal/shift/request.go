@@ -0,0 +1,56 @@
+package shift
+
+import validation "github.com/go-ozzo/ozzo-validation/v4"
+
+type OpenShiftPayload struct {
+	StaffID      string `json:"-"`
+	LocationID   string `json:"locationId"`
+	OpeningFloat *int   `json:"openingFloat"`
+}
+
+func (p OpenShiftPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.StaffID, validation.Required),
+		validation.Field(&p.OpeningFloat, validation.NotNil, validation.Min(0)),
+	)
+}
+
+type CashMovementPayload struct {
+	StaffID string           `json:"-"`
+	Type    CashMovementType `json:"type"`
+	Amount  int              `json:"amount"`
+	Reason  string           `json:"reason"`
+}
+
+func (p CashMovementPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.StaffID, validation.Required),
+		validation.Field(&p.Type, validation.Required, validation.In(CashMovementTypes...)),
+		validation.Field(&p.Amount, validation.Required, validation.Min(1)),
+		validation.Field(&p.Reason, validation.Required, validation.Length(1, 200)),
+	)
+}
+
+type CloseShiftPayload struct {
+	StaffID     string `json:"-"`
+	CountedCash *int   `json:"countedCash"`
+}
+
+func (p CloseShiftPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.StaffID, validation.Required),
+		validation.Field(&p.CountedCash, validation.NotNil, validation.Min(0)),
+	)
+}
+
+type VarianceReportPayload struct {
+	StartDate string `schema:"startDate" binding:"omitempty"`
+	EndDate   string `schema:"endDate" binding:"omitempty"`
+}
+
+func (p VarianceReportPayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.StartDate, validation.Required, validation.Date("2006-01-02")),
+		validation.Field(&p.EndDate, validation.Required, validation.Date("2006-01-02")),
+	)
+}
@@ -0,0 +1,36 @@
+package shift
+
+import "time"
+
+type ShiftResponse struct {
+	ID           string     `json:"id"`
+	StaffID      string     `json:"staffId"`
+	LocationID   string     `json:"locationId"`
+	OpeningFloat int        `json:"openingFloat"`
+	CashSales    int        `json:"cashSales"`
+	CashRefunded int        `json:"cashRefunded"`
+	CashVoided   int        `json:"cashVoided"`
+	CashMovedOut int        `json:"cashMovedOut"`
+	ExpectedCash int        `json:"expectedCash"`
+	CountedCash  *int       `json:"countedCash"`
+	Variance     *int       `json:"variance"`
+	OpenedAt     time.Time  `json:"openedAt"`
+	ClosedAt     *time.Time `json:"closedAt"`
+}
+
+type CashMovementResponse struct {
+	ID        string           `json:"id"`
+	ShiftID   string           `json:"shiftId"`
+	Type      CashMovementType `json:"type"`
+	Amount    int              `json:"amount"`
+	Reason    string           `json:"reason"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+type StaffVarianceResponse struct {
+	StaffID  string `json:"staffId"`
+	Shifts   int    `json:"shifts"`
+	Expected int    `json:"expectedCash"`
+	Counted  int    `json:"countedCash"`
+	Variance int    `json:"variance"`
+}
@@ -0,0 +1,160 @@
+package shift
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/id"
+	"github.com/citadel-corp/eniqilo-store/internal/location"
+)
+
+type Service interface {
+	Open(ctx context.Context, req OpenShiftPayload) (*ShiftResponse, error)
+	GetCurrent(ctx context.Context, staffID string) (*ShiftResponse, error)
+	AddCashMovement(ctx context.Context, req CashMovementPayload) (*CashMovementResponse, error)
+	Close(ctx context.Context, req CloseShiftPayload) (*ShiftResponse, error)
+	VarianceReport(ctx context.Context, req VarianceReportPayload) ([]*StaffVarianceResponse, error)
+}
+
+type shiftService struct {
+	repository         Repository
+	locationRepository location.Repository
+}
+
+func NewService(repository Repository, locationRepository location.Repository) Service {
+	return &shiftService{
+		repository:         repository,
+		locationRepository: locationRepository,
+	}
+}
+
+// Open implements Service.
+func (s *shiftService) Open(ctx context.Context, req OpenShiftPayload) (*ShiftResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	shift, err := s.repository.GetOpenByStaffID(ctx, req.StaffID)
+	if err != nil && !errors.Is(err, ErrShiftNotFound) {
+		return nil, err
+	}
+	if shift != nil {
+		return nil, ErrShiftAlreadyOpen
+	}
+	locationID := location.DefaultID
+	if req.LocationID != "" {
+		_, err := s.locationRepository.GetByID(ctx, req.LocationID)
+		if errors.Is(err, location.ErrLocationNotFound) {
+			return nil, ErrLocationNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		locationID = req.LocationID
+	}
+	shift = &Shift{
+		ID:           id.GenerateStringID(16),
+		StaffID:      req.StaffID,
+		LocationID:   locationID,
+		OpeningFloat: *req.OpeningFloat,
+	}
+	err = s.repository.Open(ctx, shift)
+	if err != nil {
+		return nil, err
+	}
+	return toShiftResponse(&Summary{Shift: shift, Expected: shift.OpeningFloat}), nil
+}
+
+// GetCurrent implements Service.
+func (s *shiftService) GetCurrent(ctx context.Context, staffID string) (*ShiftResponse, error) {
+	shift, err := s.repository.GetOpenByStaffID(ctx, staffID)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := s.repository.Summarize(ctx, shift)
+	if err != nil {
+		return nil, err
+	}
+	return toShiftResponse(summary), nil
+}
+
+// AddCashMovement implements Service.
+func (s *shiftService) AddCashMovement(ctx context.Context, req CashMovementPayload) (*CashMovementResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	movement := &CashMovement{
+		ID:     id.GenerateStringID(16),
+		Type:   req.Type,
+		Amount: req.Amount,
+		Reason: req.Reason,
+	}
+	err := s.repository.AddCashMovement(ctx, req.StaffID, movement)
+	if err != nil {
+		return nil, err
+	}
+	return &CashMovementResponse{
+		ID:        movement.ID,
+		ShiftID:   movement.ShiftID,
+		Type:      movement.Type,
+		Amount:    movement.Amount,
+		Reason:    movement.Reason,
+		CreatedAt: movement.CreatedAt,
+	}, nil
+}
+
+// Close implements Service.
+func (s *shiftService) Close(ctx context.Context, req CloseShiftPayload) (*ShiftResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	summary, err := s.repository.Close(ctx, req.StaffID, *req.CountedCash)
+	if err != nil {
+		return nil, err
+	}
+	return toShiftResponse(summary), nil
+}
+
+// VarianceReport implements Service.
+func (s *shiftService) VarianceReport(ctx context.Context, req VarianceReportPayload) ([]*StaffVarianceResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	variances, err := s.repository.VarianceByStaff(ctx, req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*StaffVarianceResponse, len(variances))
+	for i, v := range variances {
+		res[i] = &StaffVarianceResponse{
+			StaffID:  v.StaffID,
+			Shifts:   v.Shifts,
+			Expected: v.Expected,
+			Counted:  v.Counted,
+			Variance: v.Variance,
+		}
+	}
+	return res, nil
+}
+
+func toShiftResponse(summary *Summary) *ShiftResponse {
+	res := &ShiftResponse{
+		ID:           summary.Shift.ID,
+		StaffID:      summary.Shift.StaffID,
+		LocationID:   summary.Shift.LocationID,
+		OpeningFloat: summary.Shift.OpeningFloat,
+		CashSales:    summary.CashSales,
+		CashRefunded: summary.CashRefunded,
+		CashVoided:   summary.CashVoided,
+		CashMovedOut: summary.CashMovedOut,
+		ExpectedCash: summary.Expected,
+		CountedCash:  summary.Shift.CountedCash,
+		OpenedAt:     summary.Shift.OpenedAt,
+		ClosedAt:     summary.Shift.ClosedAt,
+	}
+	if summary.Shift.CountedCash != nil {
+		variance := *summary.Shift.CountedCash - summary.Expected
+		res.Variance = &variance
+	}
+	return res
+}
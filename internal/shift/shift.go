@@ -0,0 +1,63 @@
+package shift
+
+import "time"
+
+// Shift is a staff member's session at a cash drawer. LocationID is where
+// the register is, and sales during the shift take stock from there.
+// ExpectedCash and CountedCash are only set once the shift is closed.
+type Shift struct {
+	ID           string
+	StaffID      string
+	LocationID   string
+	OpeningFloat int
+	ExpectedCash *int
+	CountedCash  *int
+	OpenedAt     time.Time
+	ClosedAt     *time.Time
+}
+
+type CashMovementType string
+
+var (
+	MovementDrop   CashMovementType = "Drop"
+	MovementPayOut CashMovementType = "PayOut"
+)
+
+var CashMovementTypes = []interface{}{MovementDrop, MovementPayOut}
+
+// CashMovement is cash taken out of the drawer during a shift, either
+// dropped into the safe or paid out for an expense.
+type CashMovement struct {
+	ID        string
+	ShiftID   string
+	Type      CashMovementType
+	Amount    int
+	Reason    string
+	CreatedAt time.Time
+}
+
+// Summary is the cash position of a shift. Expected is what should be in the
+// drawer: the opening float plus cash taken on sales, less cash given as
+// change, cash handed back on refunds and voids and cash moved out of the
+// drawer. Refunds and voids count against the shift they were made in, not
+// the shift of the sale.
+type Summary struct {
+	Shift        *Shift
+	CashSales    int
+	CashRefunded int
+	CashVoided   int
+	CashMovedOut int
+	Expected     int
+}
+
+func (s *Summary) expectedCash() int {
+	return s.Shift.OpeningFloat + s.CashSales - s.CashRefunded - s.CashVoided - s.CashMovedOut
+}
+
+type StaffVariance struct {
+	StaffID  string
+	Shifts   int
+	Expected int
+	Counted  int
+	Variance int
+}
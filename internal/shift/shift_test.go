@@ -0,0 +1,39 @@
+package shift
+
+import "testing"
+
+func TestSummaryExpectedCash(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary Summary
+		want    int
+	}{
+		{
+			name:    "opening float only",
+			summary: Summary{Shift: &Shift{OpeningFloat: 100000}},
+			want:    100000,
+		},
+		{
+			name:    "sales and cash movements",
+			summary: Summary{Shift: &Shift{OpeningFloat: 100000}, CashSales: 75000, CashMovedOut: 50000},
+			want:    125000,
+		},
+		{
+			name:    "cash refund is taken out of the drawer",
+			summary: Summary{Shift: &Shift{OpeningFloat: 100000}, CashSales: 75000, CashRefunded: 20000, CashMovedOut: 50000},
+			want:    105000,
+		},
+		{
+			name:    "cash handed back on a void is taken out of the drawer",
+			summary: Summary{Shift: &Shift{OpeningFloat: 100000}, CashSales: 75000, CashVoided: 30000},
+			want:    145000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.summary.expectedCash(); got != tt.want {
+				t.Errorf("expectedCash() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
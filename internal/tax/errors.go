@@ -0,0 +1,9 @@
+package tax
+
+import "errors"
+
+var (
+	ErrValidationFailed = errors.New("validation failed")
+	ErrTaxRateNotFound  = errors.New("tax rate not found")
+	ErrProductNotFound  = errors.New("product not found")
+)
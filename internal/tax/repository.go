@@ -0,0 +1,92 @@
+package tax
+
+import (
+	"context"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/db"
+)
+
+type Repository interface {
+	Set(ctx context.Context, taxRate *TaxRate) error
+	List(ctx context.Context) ([]*TaxRate, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type dbRepository struct {
+	db *db.DB
+}
+
+func NewRepository(db *db.DB) Repository {
+	return &dbRepository{db: db}
+}
+
+// Set implements Repository.
+// An existing rate for the same category or product is replaced.
+func (d *dbRepository) Set(ctx context.Context, taxRate *TaxRate) error {
+	q := `
+		INSERT INTO tax_rates (
+			id, category, product_id, rate
+		) VALUES (
+			$1, $2, $3, $4
+		)
+		ON CONFLICT (category) WHERE category IS NOT NULL DO UPDATE SET rate = EXCLUDED.rate
+		RETURNING id, created_at;
+	`
+	if taxRate.ProductID != nil {
+		q = `
+			INSERT INTO tax_rates (
+				id, category, product_id, rate
+			) VALUES (
+				$1, $2, $3, $4
+			)
+			ON CONFLICT (product_id) WHERE product_id IS NOT NULL DO UPDATE SET rate = EXCLUDED.rate
+			RETURNING id, created_at;
+		`
+	}
+	row := d.db.DB().QueryRowContext(ctx, q, taxRate.ID, taxRate.Category, taxRate.ProductID, taxRate.Rate)
+	return row.Scan(&taxRate.ID, &taxRate.CreatedAt)
+}
+
+// List implements Repository.
+func (d *dbRepository) List(ctx context.Context) ([]*TaxRate, error) {
+	q := `
+		SELECT id, category, product_id, rate, created_at
+		FROM tax_rates
+		ORDER BY created_at ASC;
+	`
+	rows, err := d.db.DB().QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res := make([]*TaxRate, 0)
+	for rows.Next() {
+		t := &TaxRate{}
+		err := rows.Scan(&t.ID, &t.Category, &t.ProductID, &t.Rate, &t.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, t)
+	}
+	return res, rows.Err()
+}
+
+// Delete implements Repository.
+func (d *dbRepository) Delete(ctx context.Context, id string) error {
+	q := `
+        DELETE FROM tax_rates
+        WHERE id = $1;
+    `
+	row, err := d.db.DB().ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := row.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrTaxRateNotFound
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package tax
+
+import (
+	"errors"
+
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type SetTaxRatePayload struct {
+	Category  *product.ProductCategory `json:"category"`
+	ProductID *string                  `json:"productId"`
+	// Rate is in basis points, e.g. 1100 for 11%.
+	Rate *int `json:"rate"`
+}
+
+func (p SetTaxRatePayload) Validate() error {
+	if (p.Category == nil) == (p.ProductID == nil) {
+		return errors.New("exactly one of category or productId is required")
+	}
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Category, validation.NilOrNotEmpty, validation.In(product.ProductCategories...)),
+		validation.Field(&p.ProductID, validation.NilOrNotEmpty),
+		validation.Field(&p.Rate, validation.NotNil, validation.Min(0), validation.Max(10000)),
+	)
+}
+
+type DeleteTaxRatePayload struct {
+	ID string
+}
+
+func (p DeleteTaxRatePayload) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.ID, validation.Required),
+	)
+}
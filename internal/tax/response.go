@@ -0,0 +1,15 @@
+package tax
+
+import (
+	"time"
+
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+type TaxRateResponse struct {
+	ID        string                   `json:"id"`
+	Category  *product.ProductCategory `json:"category"`
+	ProductID *string                  `json:"productId"`
+	Rate      int                      `json:"rate"`
+	CreatedAt time.Time                `json:"createdAt"`
+}
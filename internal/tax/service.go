@@ -0,0 +1,85 @@
+package tax
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/citadel-corp/eniqilo-store/internal/common/id"
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+type Service interface {
+	Set(ctx context.Context, req SetTaxRatePayload) (*TaxRateResponse, error)
+	List(ctx context.Context) ([]*TaxRateResponse, error)
+	Delete(ctx context.Context, req DeleteTaxRatePayload) error
+}
+
+type taxService struct {
+	repository        Repository
+	productRepository product.Repository
+}
+
+func NewService(repository Repository, productRepository product.Repository) Service {
+	return &taxService{
+		repository:        repository,
+		productRepository: productRepository,
+	}
+}
+
+// Set implements Service.
+func (s *taxService) Set(ctx context.Context, req SetTaxRatePayload) (*TaxRateResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	if req.ProductID != nil {
+		products, err := s.productRepository.GetByMultipleID(ctx, []string{*req.ProductID})
+		if err != nil {
+			return nil, err
+		}
+		if len(products) == 0 {
+			return nil, ErrProductNotFound
+		}
+	}
+	taxRate := &TaxRate{
+		ID:        id.GenerateStringID(16),
+		Category:  req.Category,
+		ProductID: req.ProductID,
+		Rate:      *req.Rate,
+	}
+	err := s.repository.Set(ctx, taxRate)
+	if err != nil {
+		return nil, err
+	}
+	return toTaxRateResponse(taxRate), nil
+}
+
+// List implements Service.
+func (s *taxService) List(ctx context.Context) ([]*TaxRateResponse, error) {
+	taxRates, err := s.repository.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*TaxRateResponse, len(taxRates))
+	for i, taxRate := range taxRates {
+		res[i] = toTaxRateResponse(taxRate)
+	}
+	return res, nil
+}
+
+// Delete implements Service.
+func (s *taxService) Delete(ctx context.Context, req DeleteTaxRatePayload) error {
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	return s.repository.Delete(ctx, req.ID)
+}
+
+func toTaxRateResponse(taxRate *TaxRate) *TaxRateResponse {
+	return &TaxRateResponse{
+		ID:        taxRate.ID,
+		Category:  taxRate.Category,
+		ProductID: taxRate.ProductID,
+		Rate:      taxRate.Rate,
+		CreatedAt: taxRate.CreatedAt,
+	}
+}
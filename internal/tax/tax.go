@@ -0,0 +1,67 @@
+package tax
+
+import (
+	"os"
+	"time"
+
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+var (
+	pricingMode = os.Getenv("TAX_PRICING_MODE")
+)
+
+// Inclusive reports whether product prices already include tax. It is
+// controlled by TAX_PRICING_MODE, which is either "inclusive" or "exclusive"
+// (the default).
+func Inclusive() bool {
+	return pricingMode == "inclusive"
+}
+
+// TaxRate is a tax rate in basis points (1100 is 11%) for either a product
+// category or a single product. A product rate overrides its category's rate.
+type TaxRate struct {
+	ID        string
+	Category  *product.ProductCategory
+	ProductID *string
+	Rate      int
+	CreatedAt time.Time
+}
+
+type Rates struct {
+	byCategory map[product.ProductCategory]int
+	byProduct  map[string]int
+}
+
+func NewRates(taxRates []*TaxRate) *Rates {
+	r := &Rates{
+		byCategory: make(map[product.ProductCategory]int),
+		byProduct:  make(map[string]int),
+	}
+	for _, taxRate := range taxRates {
+		if taxRate.ProductID != nil {
+			r.byProduct[*taxRate.ProductID] = taxRate.Rate
+		}
+		if taxRate.Category != nil {
+			r.byCategory[*taxRate.Category] = taxRate.Rate
+		}
+	}
+	return r
+}
+
+// Rate returns the rate in basis points that applies to a product.
+func (r *Rates) Rate(productID string, category product.ProductCategory) int {
+	if rate, ok := r.byProduct[productID]; ok {
+		return rate
+	}
+	return r.byCategory[category]
+}
+
+// Compute returns the tax on amount at rate basis points. When inclusive is
+// true amount already contains the tax and the contained portion is returned.
+func Compute(amount int64, rate int, inclusive bool) int64 {
+	if inclusive {
+		return amount * int64(rate) / (10000 + int64(rate))
+	}
+	return amount * int64(rate) / 10000
+}
@@ -0,0 +1,51 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/citadel-corp/eniqilo-store/internal/product"
+)
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		amount    int64
+		rate      int
+		inclusive bool
+		want      int64
+	}{
+		{amount: 10000, rate: 1100, want: 1100},
+		{amount: 11100, rate: 1100, inclusive: true, want: 1100},
+		// 999 * 11% is 109.89, rounded down
+		{amount: 999, rate: 1100, want: 109},
+		// 999 holds 999 * 1100 / 11100 = 99.0 of tax
+		{amount: 999, rate: 1100, inclusive: true, want: 99},
+		{amount: 10000, rate: 0, want: 0},
+		{amount: 10000, rate: 0, inclusive: true, want: 0},
+		{amount: 0, rate: 1100, want: 0},
+		{amount: 12345, rate: 250, want: 308},
+		{amount: 12345, rate: 250, inclusive: true, want: 301},
+	}
+	for _, tt := range tests {
+		if got := Compute(tt.amount, tt.rate, tt.inclusive); got != tt.want {
+			t.Errorf("Compute(%d, %d, %v) = %d, want %d", tt.amount, tt.rate, tt.inclusive, got, tt.want)
+		}
+	}
+}
+
+func TestRatesProductOverridesCategory(t *testing.T) {
+	beverages := product.CategoryBeverages
+	productID := "p1"
+	rates := NewRates([]*TaxRate{
+		{Category: &beverages, Rate: 500},
+		{ProductID: &productID, Rate: 0},
+	})
+	if got := rates.Rate("p1", beverages); got != 0 {
+		t.Errorf("product rate = %d, want 0", got)
+	}
+	if got := rates.Rate("p2", beverages); got != 500 {
+		t.Errorf("category rate = %d, want 500", got)
+	}
+	if got := rates.Rate("p2", product.CategoryClothing); got != 0 {
+		t.Errorf("rate without a category rate = %d, want 0", got)
+	}
+}
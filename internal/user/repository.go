@@ -44,13 +44,13 @@ func (d *dbRepository) Create(ctx context.Context, user *User) error {
 // GetByUsernameAndHashedPassword implements Repository.
 func (d *dbRepository) GetByPhoneNumberAndUserType(ctx context.Context, phoneNumber string, userType UserType) (*User, error) {
 	getUserQuery := `
-		SELECT id, phone_number, name, user_type, hashed_password
+		SELECT id, phone_number, name, user_type, hashed_password, is_manager
 		FROM users
 		WHERE phone_number = $1 AND user_type = $2;
 	`
 	row := d.db.DB().QueryRowContext(ctx, getUserQuery, phoneNumber, userType)
 	u := &User{}
-	err := row.Scan(&u.ID, &u.PhoneNumber, &u.Name, &u.UserType, &u.HashedPassword)
+	err := row.Scan(&u.ID, &u.PhoneNumber, &u.Name, &u.UserType, &u.HashedPassword, &u.IsManager)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, ErrUserNotFound
 	}
@@ -62,13 +62,13 @@ func (d *dbRepository) GetByPhoneNumberAndUserType(ctx context.Context, phoneNum
 
 func (d *dbRepository) GetByID(ctx context.Context, id string) (*User, error) {
 	getUserQuery := `
-		SELECT id, phone_number, name, user_type, hashed_password
+		SELECT id, phone_number, name, user_type, hashed_password, is_manager
 		FROM users
 		WHERE id = $1;
 	`
 	row := d.db.DB().QueryRowContext(ctx, getUserQuery, id)
 	u := &User{}
-	err := row.Scan(&u.ID, &u.PhoneNumber, &u.Name, &u.UserType, &u.HashedPassword)
+	err := row.Scan(&u.ID, &u.PhoneNumber, &u.Name, &u.UserType, &u.HashedPassword, &u.IsManager)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, ErrUserNotFound
 	}
@@ -6,6 +6,9 @@ type User struct {
 	PhoneNumber    string
 	Name           string
 	HashedPassword string
+	// IsManager is only meaningful for staff. Managers may perform actions on
+	// behalf of other staff members, such as voiding their sales.
+	IsManager bool
 }
 type UserType string
 
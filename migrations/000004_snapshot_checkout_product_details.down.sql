@@ -0,0 +1,6 @@
+UPDATE checkout_histories ch
+SET product_details = (
+    SELECT jsonb_agg(d.detail - 'Name' - 'SKU' - 'Category' - 'Price' - 'Total' ORDER BY d.ord)
+    FROM jsonb_array_elements(ch.product_details) WITH ORDINALITY AS d(detail, ord)
+)
+WHERE jsonb_array_length(ch.product_details) > 0;
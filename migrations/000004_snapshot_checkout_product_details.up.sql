@@ -0,0 +1,19 @@
+-- Backfill the price, name, SKU and category snapshot of every checkout line
+-- from the current product row. Lines whose product has since been deleted are
+-- left untouched since there is nothing to recover them from.
+UPDATE checkout_histories ch
+SET product_details = (
+    SELECT jsonb_agg(
+        d.detail || jsonb_strip_nulls(jsonb_build_object(
+            'Name', p.name,
+            'SKU', p.sku,
+            'Category', p.category::text,
+            'Price', p.price,
+            'Total', p.price * (d.detail->>'Quantity')::int
+        ))
+        ORDER BY d.ord
+    )
+    FROM jsonb_array_elements(ch.product_details) WITH ORDINALITY AS d(detail, ord)
+    LEFT JOIN products p ON p.id = d.detail->>'ProductID'
+)
+WHERE jsonb_array_length(ch.product_details) > 0;
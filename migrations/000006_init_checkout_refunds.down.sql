@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS checkout_refunds_checkout_history_id;
+
+DROP TABLE IF EXISTS checkout_refunds;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS damaged_stock;
@@ -0,0 +1,17 @@
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS damaged_stock INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS
+checkout_refunds (
+    id VARCHAR(16) PRIMARY KEY,
+    checkout_history_id VARCHAR(16) NOT NULL,
+    product_details JSONB NOT NULL,
+    amount INT NOT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp
+);
+
+ALTER TABLE checkout_refunds
+	ADD CONSTRAINT fk_checkout_history_id FOREIGN KEY (checkout_history_id) REFERENCES checkout_histories(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS checkout_refunds_checkout_history_id
+	ON checkout_refunds USING HASH(checkout_history_id);
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS checkout_histories_voided;
+
+ALTER TABLE checkout_histories
+    DROP CONSTRAINT IF EXISTS fk_staff_id;
+
+ALTER TABLE checkout_histories
+    DROP COLUMN IF EXISTS voided_by,
+    DROP COLUMN IF EXISTS voided_at,
+    DROP COLUMN IF EXISTS staff_id;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS is_manager;
@@ -0,0 +1,13 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS is_manager BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE checkout_histories
+    ADD COLUMN IF NOT EXISTS staff_id VARCHAR(16),
+    ADD COLUMN IF NOT EXISTS voided_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS voided_by VARCHAR(16);
+
+ALTER TABLE checkout_histories
+	ADD CONSTRAINT fk_staff_id FOREIGN KEY (staff_id) REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS checkout_histories_voided
+	ON checkout_histories (voided_at) WHERE voided_at IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS promotions_active;
+
+DROP TABLE IF EXISTS promotions;
+
+DROP TYPE IF EXISTS promotion_types;
@@ -0,0 +1,23 @@
+CREATE TYPE promotion_types AS ENUM('Percentage', 'FixedAmount', 'BuyXGetY');
+
+CREATE TABLE IF NOT EXISTS
+promotions (
+    id VARCHAR(16) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    promotion_type promotion_types NOT NULL,
+    product_id VARCHAR(16),
+    category product_categories,
+    percentage INT NOT NULL DEFAULT 0,
+    amount INT NOT NULL DEFAULT 0,
+    buy_quantity INT NOT NULL DEFAULT 0,
+    get_quantity INT NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp
+);
+
+ALTER TABLE promotions
+	ADD CONSTRAINT fk_product_id FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS promotions_active
+	ON promotions(starts_at, ends_at);
@@ -0,0 +1,8 @@
+ALTER TABLE checkout_histories
+    DROP COLUMN IF EXISTS tax_inclusive,
+    DROP COLUMN IF EXISTS tax;
+
+DROP INDEX IF EXISTS tax_rates_product_id;
+DROP INDEX IF EXISTS tax_rates_category;
+
+DROP TABLE IF EXISTS tax_rates;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS
+tax_rates (
+    id VARCHAR(16) PRIMARY KEY,
+    category product_categories,
+    product_id VARCHAR(16),
+    rate INT NOT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    CHECK ((category IS NULL) <> (product_id IS NULL))
+);
+
+ALTER TABLE tax_rates
+	ADD CONSTRAINT fk_product_id FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS tax_rates_category
+	ON tax_rates(category) WHERE category IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS tax_rates_product_id
+	ON tax_rates(product_id) WHERE product_id IS NOT NULL;
+
+ALTER TABLE checkout_histories
+    ADD COLUMN IF NOT EXISTS tax INT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax_inclusive BOOLEAN NOT NULL DEFAULT false;
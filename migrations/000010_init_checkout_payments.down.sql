@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS checkout_payments_method;
+DROP INDEX IF EXISTS checkout_payments_checkout_history_id;
+
+DROP TABLE IF EXISTS checkout_payments;
+
+DROP TYPE IF EXISTS payment_methods;
@@ -0,0 +1,24 @@
+CREATE TYPE payment_methods AS ENUM('Cash', 'Card', 'EWallet', 'Voucher');
+
+CREATE TABLE IF NOT EXISTS
+checkout_payments (
+    id VARCHAR(16) PRIMARY KEY,
+    checkout_history_id VARCHAR(16) NOT NULL,
+    method payment_methods NOT NULL,
+    amount INT NOT NULL,
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT current_timestamp
+);
+
+ALTER TABLE checkout_payments
+	ADD CONSTRAINT fk_checkout_history_id FOREIGN KEY (checkout_history_id) REFERENCES checkout_histories(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS checkout_payments_checkout_history_id
+	ON checkout_payments USING HASH(checkout_history_id);
+CREATE INDEX IF NOT EXISTS checkout_payments_method
+	ON checkout_payments(method);
+
+-- every checkout made before split tender was paid in cash
+INSERT INTO checkout_payments (id, checkout_history_id, method, amount, created_at)
+SELECT left(md5(id), 16), id, 'Cash', paid, created_at
+FROM checkout_histories;
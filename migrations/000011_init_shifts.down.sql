@@ -0,0 +1,18 @@
+DROP INDEX IF EXISTS checkout_histories_shift_id;
+
+ALTER TABLE checkout_histories
+    DROP CONSTRAINT IF EXISTS fk_shift_id;
+
+ALTER TABLE checkout_histories
+    DROP COLUMN IF EXISTS shift_id;
+
+DROP INDEX IF EXISTS shift_cash_movements_shift_id;
+
+DROP TABLE IF EXISTS shift_cash_movements;
+
+DROP INDEX IF EXISTS shifts_closed_at;
+DROP INDEX IF EXISTS shifts_open_staff_id;
+
+DROP TABLE IF EXISTS shifts;
+
+DROP TYPE IF EXISTS cash_movement_types;
@@ -0,0 +1,46 @@
+CREATE TYPE cash_movement_types AS ENUM('Drop', 'PayOut');
+
+CREATE TABLE IF NOT EXISTS
+shifts (
+    id VARCHAR(16) PRIMARY KEY,
+    staff_id VARCHAR(16) NOT NULL,
+    opening_float INT NOT NULL,
+    expected_cash INT,
+    counted_cash INT,
+    opened_at TIMESTAMP DEFAULT current_timestamp,
+    closed_at TIMESTAMP
+);
+
+ALTER TABLE shifts
+	ADD CONSTRAINT fk_staff_id FOREIGN KEY (staff_id) REFERENCES users(id) ON DELETE CASCADE;
+
+-- a staff member can only have one open shift at a time
+CREATE UNIQUE INDEX IF NOT EXISTS shifts_open_staff_id
+	ON shifts(staff_id) WHERE closed_at IS NULL;
+CREATE INDEX IF NOT EXISTS shifts_closed_at
+	ON shifts(closed_at);
+
+CREATE TABLE IF NOT EXISTS
+shift_cash_movements (
+    id VARCHAR(16) PRIMARY KEY,
+    shift_id VARCHAR(16) NOT NULL,
+    movement_type cash_movement_types NOT NULL,
+    amount INT NOT NULL,
+    reason VARCHAR(200) NOT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp
+);
+
+ALTER TABLE shift_cash_movements
+	ADD CONSTRAINT fk_shift_id FOREIGN KEY (shift_id) REFERENCES shifts(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS shift_cash_movements_shift_id
+	ON shift_cash_movements USING HASH(shift_id);
+
+ALTER TABLE checkout_histories
+    ADD COLUMN IF NOT EXISTS shift_id VARCHAR(16);
+
+ALTER TABLE checkout_histories
+	ADD CONSTRAINT fk_shift_id FOREIGN KEY (shift_id) REFERENCES shifts(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS checkout_histories_shift_id
+	ON checkout_histories USING HASH(shift_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS parked_sales_expires_at;
+DROP INDEX IF EXISTS parked_sales_register;
+DROP INDEX IF EXISTS parked_sales_staff_id;
+
+DROP TABLE IF EXISTS parked_sales;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS
+parked_sales (
+    id VARCHAR(16) PRIMARY KEY,
+    staff_id VARCHAR(16) NOT NULL,
+    register VARCHAR(50) NOT NULL DEFAULT '',
+    customer_id VARCHAR(16) NOT NULL,
+    product_details JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    expires_at TIMESTAMP NOT NULL
+);
+
+ALTER TABLE parked_sales
+	ADD CONSTRAINT fk_staff_id FOREIGN KEY (staff_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE parked_sales
+	ADD CONSTRAINT fk_customer_id FOREIGN KEY (customer_id) REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS parked_sales_staff_id
+	ON parked_sales USING HASH(staff_id);
+CREATE INDEX IF NOT EXISTS parked_sales_register
+	ON parked_sales USING HASH(register);
+CREATE INDEX IF NOT EXISTS parked_sales_expires_at
+	ON parked_sales(expires_at);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS
+carts (
+    id VARCHAR(16) PRIMARY KEY,
+    customer_id VARCHAR(16),
+    created_at TIMESTAMP DEFAULT current_timestamp
+);
+
+ALTER TABLE carts
+	ADD CONSTRAINT fk_customer_id FOREIGN KEY (customer_id) REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS
+cart_items (
+    cart_id VARCHAR(16) NOT NULL,
+    product_id VARCHAR(16) NOT NULL,
+    quantity INT NOT NULL,
+    price_at_add INT NOT NULL,
+    added_at TIMESTAMP DEFAULT current_timestamp,
+    PRIMARY KEY (cart_id, product_id)
+);
+
+ALTER TABLE cart_items
+	ADD CONSTRAINT fk_cart_id FOREIGN KEY (cart_id) REFERENCES carts(id) ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS checkout_histories_paid;
+DROP INDEX IF EXISTS checkout_histories_staff_id;
+DROP INDEX IF EXISTS checkout_histories_product_details;
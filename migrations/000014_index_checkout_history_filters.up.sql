@@ -0,0 +1,6 @@
+CREATE INDEX IF NOT EXISTS checkout_histories_product_details
+	ON checkout_histories USING GIN(product_details jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS checkout_histories_staff_id
+	ON checkout_histories USING HASH(staff_id);
+CREATE INDEX IF NOT EXISTS checkout_histories_paid
+	ON checkout_histories(paid);
@@ -0,0 +1,13 @@
+-- enum values cannot be dropped, so 'Points' stays on payment_methods
+
+DROP INDEX IF EXISTS loyalty_entries_checkout_history_id;
+DROP INDEX IF EXISTS loyalty_entries_user_id_created_at;
+
+DROP TABLE IF EXISTS loyalty_entries;
+
+DROP TYPE IF EXISTS loyalty_entry_types;
+
+DROP TABLE IF EXISTS loyalty_multipliers;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS loyalty_points;
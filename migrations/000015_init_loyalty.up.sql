@@ -0,0 +1,36 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS loyalty_points INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS
+loyalty_multipliers (
+    category product_categories PRIMARY KEY,
+    multiplier INT NOT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp
+);
+
+CREATE TYPE loyalty_entry_types AS ENUM('Earn', 'Redeem', 'Clawback', 'Reversal');
+
+CREATE TABLE IF NOT EXISTS
+loyalty_entries (
+    id VARCHAR(16) PRIMARY KEY,
+    user_id VARCHAR(16) NOT NULL,
+    checkout_history_id VARCHAR(16),
+    refund_id VARCHAR(16),
+    type loyalty_entry_types NOT NULL,
+    points INT NOT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp
+);
+
+ALTER TABLE loyalty_entries
+	ADD CONSTRAINT fk_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE loyalty_entries
+	ADD CONSTRAINT fk_checkout_history_id FOREIGN KEY (checkout_history_id) REFERENCES checkout_histories(id) ON DELETE SET NULL;
+ALTER TABLE loyalty_entries
+	ADD CONSTRAINT fk_refund_id FOREIGN KEY (refund_id) REFERENCES checkout_refunds(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS loyalty_entries_user_id_created_at
+	ON loyalty_entries(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS loyalty_entries_checkout_history_id
+	ON loyalty_entries USING HASH(checkout_history_id);
+
+ALTER TYPE payment_methods ADD VALUE IF NOT EXISTS 'Points';
@@ -0,0 +1,17 @@
+-- enum values cannot be dropped, so 'GiftCard' and 'StoreCredit' stay on
+-- payment_methods
+
+ALTER TABLE checkout_refunds
+    DROP COLUMN IF EXISTS store_credit;
+
+DROP INDEX IF EXISTS gift_card_transactions_checkout_history_id;
+DROP INDEX IF EXISTS gift_card_transactions_gift_card_id_created_at;
+
+DROP TABLE IF EXISTS gift_card_transactions;
+
+DROP TYPE IF EXISTS gift_card_transaction_types;
+
+DROP INDEX IF EXISTS gift_cards_customer_id;
+DROP INDEX IF EXISTS gift_cards_code;
+
+DROP TABLE IF EXISTS gift_cards;
@@ -0,0 +1,48 @@
+CREATE TABLE IF NOT EXISTS
+gift_cards (
+    id VARCHAR(16) PRIMARY KEY,
+    code VARCHAR(16),
+    customer_id VARCHAR(16),
+    balance INT NOT NULL CHECK (balance >= 0),
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    CHECK ((code IS NULL) <> (customer_id IS NULL))
+);
+
+ALTER TABLE gift_cards
+	ADD CONSTRAINT fk_customer_id FOREIGN KEY (customer_id) REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS gift_cards_code
+	ON gift_cards(code) WHERE code IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS gift_cards_customer_id
+	ON gift_cards(customer_id) WHERE customer_id IS NOT NULL;
+
+CREATE TYPE gift_card_transaction_types AS ENUM('Issue', 'Redeem', 'Credit', 'Reversal');
+
+CREATE TABLE IF NOT EXISTS
+gift_card_transactions (
+    id VARCHAR(16) PRIMARY KEY,
+    gift_card_id VARCHAR(16) NOT NULL,
+    checkout_history_id VARCHAR(16),
+    refund_id VARCHAR(16),
+    type gift_card_transaction_types NOT NULL,
+    amount INT NOT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp
+);
+
+ALTER TABLE gift_card_transactions
+	ADD CONSTRAINT fk_gift_card_id FOREIGN KEY (gift_card_id) REFERENCES gift_cards(id) ON DELETE CASCADE;
+ALTER TABLE gift_card_transactions
+	ADD CONSTRAINT fk_checkout_history_id FOREIGN KEY (checkout_history_id) REFERENCES checkout_histories(id) ON DELETE SET NULL;
+ALTER TABLE gift_card_transactions
+	ADD CONSTRAINT fk_refund_id FOREIGN KEY (refund_id) REFERENCES checkout_refunds(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS gift_card_transactions_gift_card_id_created_at
+	ON gift_card_transactions(gift_card_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS gift_card_transactions_checkout_history_id
+	ON gift_card_transactions USING HASH(checkout_history_id);
+
+ALTER TABLE checkout_refunds
+    ADD COLUMN IF NOT EXISTS store_credit BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TYPE payment_methods ADD VALUE IF NOT EXISTS 'GiftCard';
+ALTER TYPE payment_methods ADD VALUE IF NOT EXISTS 'StoreCredit';
@@ -0,0 +1,2 @@
+ALTER TABLE checkout_histories
+    DROP COLUMN IF EXISTS rounding;
@@ -0,0 +1,2 @@
+ALTER TABLE checkout_histories
+    ADD COLUMN IF NOT EXISTS rounding INT NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS stock_movements_product_id_created_at;
+
+DROP TABLE IF EXISTS stock_movements;
+
+DROP TYPE IF EXISTS stock_movement_reasons;
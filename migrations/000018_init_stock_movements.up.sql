@@ -0,0 +1,16 @@
+CREATE TYPE stock_movement_reasons AS ENUM('Sale', 'Refund', 'Void', 'Adjustment', 'Receiving', 'WriteOff');
+
+CREATE TABLE IF NOT EXISTS
+stock_movements (
+    id VARCHAR(16) PRIMARY KEY,
+    product_id VARCHAR(16) NOT NULL,
+    delta INT NOT NULL,
+    balance INT NOT NULL,
+    reason stock_movement_reasons NOT NULL,
+    actor_id VARCHAR(16),
+    reference_id VARCHAR(16),
+    created_at TIMESTAMP DEFAULT current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS stock_movements_product_id_created_at
+	ON stock_movements(product_id, created_at DESC);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS products_low_stock;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS low_stock_alerted_at,
+    DROP COLUMN IF EXISTS reorder_point;
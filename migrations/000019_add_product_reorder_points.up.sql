@@ -0,0 +1,6 @@
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS reorder_point INT,
+    ADD COLUMN IF NOT EXISTS low_stock_alerted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS products_low_stock
+	ON products(id) WHERE stock <= reorder_point;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS purchase_order_lines_product_id;
+DROP INDEX IF EXISTS purchase_orders_supplier_id_created_at;
+
+DROP TABLE IF EXISTS purchase_order_lines;
+
+DROP TABLE IF EXISTS purchase_orders;
+
+DROP TYPE IF EXISTS purchase_order_statuses;
+
+DROP TABLE IF EXISTS suppliers;
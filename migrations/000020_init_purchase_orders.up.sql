@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS
+suppliers (
+    id VARCHAR(16) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    contact_name VARCHAR(50) NOT NULL DEFAULT '',
+    phone_number VARCHAR(16) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT current_timestamp
+);
+
+CREATE TYPE purchase_order_statuses AS ENUM('Open', 'Received');
+
+CREATE TABLE IF NOT EXISTS
+purchase_orders (
+    id VARCHAR(16) PRIMARY KEY,
+    supplier_id VARCHAR(16) NOT NULL,
+    staff_id VARCHAR(16) NOT NULL,
+    status purchase_order_statuses NOT NULL,
+    created_at TIMESTAMP DEFAULT current_timestamp,
+    closed_at TIMESTAMP
+);
+
+ALTER TABLE purchase_orders
+	ADD CONSTRAINT fk_supplier_id FOREIGN KEY (supplier_id) REFERENCES suppliers(id) ON DELETE RESTRICT;
+
+CREATE TABLE IF NOT EXISTS
+purchase_order_lines (
+    purchase_order_id VARCHAR(16) NOT NULL,
+    product_id VARCHAR(16) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    received_quantity INT NOT NULL DEFAULT 0 CHECK (received_quantity >= 0 AND received_quantity <= quantity),
+    unit_cost INT NOT NULL,
+    PRIMARY KEY (purchase_order_id, product_id)
+);
+
+ALTER TABLE purchase_order_lines
+	ADD CONSTRAINT fk_purchase_order_id FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS purchase_orders_supplier_id_created_at
+	ON purchase_orders(supplier_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS purchase_order_lines_product_id
+	ON purchase_order_lines USING HASH(product_id);
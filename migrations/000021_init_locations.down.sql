@@ -0,0 +1,33 @@
+DROP INDEX IF EXISTS stock_movements_location_id_created_at;
+DROP INDEX IF EXISTS product_stocks_location_id;
+
+ALTER TABLE purchase_orders
+    DROP COLUMN IF EXISTS location_id;
+
+ALTER TABLE checkout_histories
+    DROP COLUMN IF EXISTS location_id;
+
+ALTER TABLE shifts
+    DROP COLUMN IF EXISTS location_id;
+
+ALTER TABLE stock_movements
+    DROP COLUMN IF EXISTS location_id;
+
+ALTER TABLE products
+    ADD COLUMN IF NOT EXISTS location VARCHAR(200) NOT NULL DEFAULT '';
+
+-- a product goes back to the location holding most of its stock
+UPDATE products p
+SET location = product_locations.name
+FROM (
+    SELECT DISTINCT ON (ps.product_id) ps.product_id, l.name
+    FROM product_stocks ps
+    JOIN locations l ON l.id = ps.location_id
+    WHERE ps.location_id <> 'default'
+    ORDER BY ps.product_id, ps.stock DESC
+) AS product_locations
+WHERE product_locations.product_id = p.id;
+
+DROP TABLE IF EXISTS product_stocks;
+
+DROP TABLE IF EXISTS locations;
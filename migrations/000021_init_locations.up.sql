@@ -0,0 +1,73 @@
+CREATE TABLE IF NOT EXISTS
+locations (
+    id VARCHAR(16) PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT current_timestamp
+);
+
+INSERT INTO locations (id, name) VALUES ('default', 'Default');
+
+CREATE TABLE IF NOT EXISTS
+product_stocks (
+    product_id VARCHAR(16) NOT NULL,
+    location_id VARCHAR(16) NOT NULL,
+    stock INT NOT NULL CHECK (stock >= 0),
+    PRIMARY KEY (product_id, location_id)
+);
+
+ALTER TABLE product_stocks
+	ADD CONSTRAINT fk_product_id FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE;
+ALTER TABLE product_stocks
+	ADD CONSTRAINT fk_location_id FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE RESTRICT;
+
+-- every free-text location a product was kept at becomes a location of its
+-- own, and the product's stock is moved there
+INSERT INTO locations (id, name)
+SELECT substr(md5(name), 1, 16), name
+FROM (
+    SELECT DISTINCT left(btrim(location), 50) AS name
+    FROM products
+    WHERE btrim(location) <> ''
+) AS product_locations
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO product_stocks (product_id, location_id, stock)
+SELECT p.id, COALESCE(l.id, 'default'), p.stock
+FROM products p
+LEFT JOIN locations l ON l.name = left(btrim(p.location), 50);
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS location;
+
+ALTER TABLE stock_movements
+    ADD COLUMN IF NOT EXISTS location_id VARCHAR(16) NOT NULL DEFAULT 'default';
+ALTER TABLE stock_movements
+    ALTER COLUMN location_id DROP DEFAULT;
+ALTER TABLE stock_movements
+	ADD CONSTRAINT fk_location_id FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE RESTRICT;
+
+ALTER TABLE shifts
+    ADD COLUMN IF NOT EXISTS location_id VARCHAR(16) NOT NULL DEFAULT 'default';
+ALTER TABLE shifts
+    ALTER COLUMN location_id DROP DEFAULT;
+ALTER TABLE shifts
+	ADD CONSTRAINT fk_location_id FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE RESTRICT;
+
+ALTER TABLE checkout_histories
+    ADD COLUMN IF NOT EXISTS location_id VARCHAR(16) NOT NULL DEFAULT 'default';
+ALTER TABLE checkout_histories
+    ALTER COLUMN location_id DROP DEFAULT;
+ALTER TABLE checkout_histories
+	ADD CONSTRAINT fk_location_id FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE RESTRICT;
+
+ALTER TABLE purchase_orders
+    ADD COLUMN IF NOT EXISTS location_id VARCHAR(16) NOT NULL DEFAULT 'default';
+ALTER TABLE purchase_orders
+    ALTER COLUMN location_id DROP DEFAULT;
+ALTER TABLE purchase_orders
+	ADD CONSTRAINT fk_location_id FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE RESTRICT;
+
+CREATE INDEX IF NOT EXISTS product_stocks_location_id
+	ON product_stocks USING HASH(location_id);
+CREATE INDEX IF NOT EXISTS stock_movements_location_id_created_at
+	ON stock_movements(location_id, created_at DESC);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS checkout_refunds_shift_id;
+
+ALTER TABLE checkout_refunds
+    DROP CONSTRAINT IF EXISTS fk_shift_id;
+
+ALTER TABLE checkout_refunds
+    DROP COLUMN IF EXISTS shift_id;
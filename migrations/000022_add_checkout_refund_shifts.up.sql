@@ -0,0 +1,8 @@
+ALTER TABLE checkout_refunds
+    ADD COLUMN IF NOT EXISTS shift_id VARCHAR(16);
+
+ALTER TABLE checkout_refunds
+	ADD CONSTRAINT fk_shift_id FOREIGN KEY (shift_id) REFERENCES shifts(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS checkout_refunds_shift_id
+	ON checkout_refunds USING HASH(shift_id);
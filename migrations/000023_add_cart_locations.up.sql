@@ -0,0 +1,6 @@
+ALTER TABLE carts
+    ADD COLUMN IF NOT EXISTS location_id VARCHAR(16) NOT NULL DEFAULT 'default';
+ALTER TABLE carts
+    ALTER COLUMN location_id DROP DEFAULT;
+ALTER TABLE carts
+	ADD CONSTRAINT fk_location_id FOREIGN KEY (location_id) REFERENCES locations(id) ON DELETE RESTRICT;
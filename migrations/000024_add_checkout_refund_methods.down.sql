@@ -0,0 +1,2 @@
+ALTER TABLE checkout_refunds
+    DROP COLUMN IF EXISTS method;
@@ -0,0 +1,7 @@
+ALTER TABLE checkout_refunds
+    ADD COLUMN IF NOT EXISTS method payment_methods;
+
+-- refunds were paid out in cash unless they went to store credit
+UPDATE checkout_refunds
+SET method = 'Cash'
+WHERE NOT store_credit;
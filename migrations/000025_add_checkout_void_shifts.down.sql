@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS checkout_histories_void_shift_id;
+
+ALTER TABLE checkout_histories
+    DROP CONSTRAINT IF EXISTS fk_void_shift_id;
+
+ALTER TABLE checkout_histories
+    DROP COLUMN IF EXISTS void_shift_id;
@@ -0,0 +1,14 @@
+ALTER TABLE checkout_histories
+    ADD COLUMN IF NOT EXISTS void_shift_id VARCHAR(16);
+
+ALTER TABLE checkout_histories
+	ADD CONSTRAINT fk_void_shift_id FOREIGN KEY (void_shift_id) REFERENCES shifts(id) ON DELETE SET NULL;
+
+-- voids so far were left out of the shift the sale was made in, which is the
+-- same as handing the cash back in that shift
+UPDATE checkout_histories
+SET void_shift_id = shift_id
+WHERE voided_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS checkout_histories_void_shift_id
+	ON checkout_histories USING HASH(void_shift_id);
@@ -0,0 +1,5 @@
+-- carts made so far get a token nobody holds, so only staff can still use them
+ALTER TABLE carts
+    ADD COLUMN IF NOT EXISTS token VARCHAR(32) NOT NULL DEFAULT md5(random()::text);
+ALTER TABLE carts
+    ALTER COLUMN token DROP DEFAULT;